@@ -0,0 +1,143 @@
+package zarr
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"gocloud.dev/blob"
+)
+
+// ExportArchive streams the array's metadata and all stored chunks into w as
+// a zstd-compressed tar archive. Chunks are copied as stored, without being
+// decompressed, so the archive can be restored with ImportArchive without
+// re-encoding. Only .zarray, .zattrs, the array's chunk keys and, for a
+// deduplicated array, the blobs its chunks refer to are exported; other
+// objects under the array's prefix, such as a nested group, are left out.
+func (r *Reader) ExportArchive(ctx context.Context, w io.Writer) error {
+	zw, err := zstd.NewWriter(w)
+	if err != nil {
+		return fmt.Errorf("failed to init zstd encoder: %w", err)
+	}
+	tw := tar.NewWriter(zw)
+
+	iter := r.store.bucket.List(&blob.ListOptions{Prefix: r.prefix})
+	for {
+		obj, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			zw.Close()
+			return fmt.Errorf("failed to list array objects: %w", err)
+		}
+		if !r.isArrayObject(strings.TrimPrefix(obj.Key, r.prefix)) {
+			continue
+		}
+		if err := r.exportObject(ctx, tw, obj); err != nil {
+			zw.Close()
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		zw.Close()
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish zstd stream: %w", err)
+	}
+	return nil
+}
+
+// isArrayObject reports whether name, relative to the array, is one of the
+// array's own metadata files or chunks.
+func (r *Reader) isArrayObject(name string) bool {
+	if name == ".zarray" || name == ".zattrs" || name == dedupMarker {
+		return true
+	}
+	if hash, ok := strings.CutPrefix(name, blobDir); ok {
+		return len(hash) == sha256.Size*2 && !strings.Contains(hash, "/")
+	}
+	_, ok := r.parseChunkKey(name)
+	return ok
+}
+
+// exportObject copies a single bucket object into the archive.
+func (r *Reader) exportObject(ctx context.Context, tw *tar.Writer, obj *blob.ListObject) error {
+	name := obj.Key[len(r.prefix):]
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    obj.Size,
+		ModTime: obj.ModTime,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write archive header for %s: %w", name, err)
+	}
+
+	reader, err := r.store.bucket.NewReader(ctx, obj.Key, nil)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer reader.Close()
+
+	if _, err := io.Copy(tw, reader); err != nil {
+		return fmt.Errorf("failed to archive %s: %w", name, err)
+	}
+	return nil
+}
+
+// ImportArchive restores an archive written by ExportArchive into the bucket
+// at the given gocloud URL, which can then be opened with NewReader.
+func ImportArchive(ctx context.Context, src io.Reader, path string) error {
+	bucket, err := blob.OpenBucket(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to create bucket: %w", err)
+	}
+	defer bucket.Close()
+
+	zr, err := zstd.NewReader(src)
+	if err != nil {
+		return fmt.Errorf("failed to init zstd decoder: %w", err)
+	}
+	defer zr.Close()
+
+	tr := tar.NewReader(zr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := importObject(ctx, bucket, hdr.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// importObject writes a single archive entry to the bucket.
+func importObject(ctx context.Context, bucket *blob.Bucket, key string, src io.Reader) error {
+	w, err := bucket.NewWriter(ctx, key, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", key, err)
+	}
+	if _, err := io.Copy(w, src); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package zarr_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_ExportImportArchive(t *testing.T) {
+	src := openSequential(t, []int{5, 3}, []int{2, 2})
+	ctx := context.Background()
+
+	var archive bytes.Buffer
+	if err := src.ExportArchive(ctx, &archive); err != nil {
+		t.Fatalf("ExportArchive failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := zarr.ImportArchive(ctx, &archive, "file:///"+filepath.ToSlash(dir)); err != nil {
+		t.Fatalf("ImportArchive failed: %v", err)
+	}
+	dst := openReader(t, dir)
+
+	want, err := src.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull on source failed: %v", err)
+	}
+	got, err := dst.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull on imported array failed: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("imported array differs from source.\nExpected: %v\nGot:      %v", decodeFloat32(want), decodeFloat32(got))
+	}
+}
+
+func TestReader_ExportArchiveOwnKeys(t *testing.T) {
+	for _, sep := range []string{".", "/"} {
+		t.Run(sep, func(t *testing.T) {
+			key := func(i, j int) string { return zarr.ChunkKey([]int{i, j}, sep) }
+			fb := newFakeBucket(map[string][]byte{
+				"arr/.zarray": []byte(`{
+					"zarr_format": 2,
+					"shape": [2, 4],
+					"chunks": [2, 2],
+					"dtype": "<i4",
+					"compressor": null,
+					"fill_value": 0,
+					"order": "C",
+					"dimension_separator": "` + sep + `"
+				}`),
+				"arr/.zattrs":      []byte(`{"units": "m"}`),
+				"arr/" + key(0, 0): encodeLE(t, []int32{1, 2, 3, 4}),
+				"arr/" + key(0, 1): encodeLE(t, []int32{5, 6, 7, 8}),
+				// A sub-group nested under the array's prefix, and a sibling
+				// array sharing the prefix string.
+				"arr/sub/.zgroup":   []byte(`{"zarr_format": 2}`),
+				"arr/sub/x/.zarray": []byte(`{}`),
+				"arr/sub/x/0":       []byte("other"),
+				"arr/notes.txt":     []byte("notes"),
+				"arr2/.zarray":      []byte(`{}`),
+				"arr2/" + key(0, 0): []byte("other"),
+			})
+			reader := openFakeArray(t, fb, "arr")
+
+			var archive bytes.Buffer
+			if err := reader.ExportArchive(context.Background(), &archive); err != nil {
+				t.Fatalf("ExportArchive failed: %v", err)
+			}
+
+			zr, err := zstd.NewReader(&archive)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer zr.Close()
+			var names []string
+			tr := tar.NewReader(zr)
+			for {
+				hdr, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("failed to read archive: %v", err)
+				}
+				names = append(names, hdr.Name)
+			}
+
+			slices.Sort(names)
+			want := []string{".zarray", ".zattrs", key(0, 0), key(0, 1)}
+			slices.Sort(want)
+			if !slices.Equal(names, want) {
+				t.Errorf("expected archive entries %q, got %q", want, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package zarr
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/endian"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// arrowTypes maps the dtype names returned by ParseDType to the Arrow types
+// with the same memory layout.
+var arrowTypes = map[string]arrow.DataType{
+	"int8":    arrow.PrimitiveTypes.Int8,
+	"int16":   arrow.PrimitiveTypes.Int16,
+	"int32":   arrow.PrimitiveTypes.Int32,
+	"int64":   arrow.PrimitiveTypes.Int64,
+	"uint8":   arrow.PrimitiveTypes.Uint8,
+	"uint16":  arrow.PrimitiveTypes.Uint16,
+	"uint32":  arrow.PrimitiveTypes.Uint32,
+	"uint64":  arrow.PrimitiveTypes.Uint64,
+	"float16": arrow.FixedWidthTypes.Float16,
+	"float32": arrow.PrimitiveTypes.Float32,
+	"float64": arrow.PrimitiveTypes.Float64,
+}
+
+// ToArrow reads a 1-D numeric array into an Arrow record with a single
+// non-nullable column of the matching primitive type, named after the last
+// element of the array's path, or "values" for an array at the bucket root.
+// The column's data buffer is the one ReadFull returns, so no element is
+// copied on little-endian hosts. Other shapes and dtypes are rejected. The
+// caller owns the record and should Release it when done.
+func (r *Reader) ToArrow(ctx context.Context, opts ...ReadOption) (arrow.Record, error) {
+	if len(r.meta.Shape) != 1 {
+		return nil, fmt.Errorf("cannot convert a %d-D array to Arrow: only 1-D arrays are supported", len(r.meta.Shape))
+	}
+	if r.rawItemSize > 0 {
+		return nil, fmt.Errorf("raw dtype views cannot be converted to Arrow")
+	}
+	name, size, err := ParseDType(r.meta.DType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dtype: %w", err)
+	}
+	typ, ok := arrowTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("dtype %s has no Arrow equivalent", r.meta.DType)
+	}
+
+	data, err := r.ReadFull(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if endian.IsBigEndian {
+		// Arrow buffers are in host order; ReadFull returns little-endian.
+		SwapBytes(data, size)
+	}
+
+	n := r.meta.Shape[0]
+	arrData := array.NewData(typ, n, []*memory.Buffer{nil, memory.NewBufferBytes(data)}, nil, 0, 0)
+	defer arrData.Release()
+	col := array.MakeFromData(arrData)
+	defer col.Release()
+
+	column := "values"
+	if p := r.Path(); p != "" {
+		column = path.Base(p)
+	}
+	schema := arrow.NewSchema([]arrow.Field{{Name: column, Type: typ}}, nil)
+	return array.NewRecord(schema, []arrow.Array{col}, int64(n)), nil
+}
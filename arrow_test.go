@@ -0,0 +1,98 @@
+package zarr_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+func TestReader_ToArrow(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("int64", func(t *testing.T) {
+		dir := t.TempDir()
+		writeArray(t, dir, int64Vector6, map[string][]byte{
+			"0": encodeLE(t, []int64{-1, 2, -3, 4}),
+			"1": encodeLE(t, []int64{1 << 40, -(1 << 40), 0, 0}),
+		})
+
+		rec, err := openReader(t, dir).ToArrow(ctx)
+		if err != nil {
+			t.Fatalf("ToArrow failed: %v", err)
+		}
+		defer rec.Release()
+
+		if rec.NumCols() != 1 || rec.NumRows() != 6 {
+			t.Fatalf("expected 1 column of 6 rows, got %d of %d", rec.NumCols(), rec.NumRows())
+		}
+		if name := rec.ColumnName(0); name != "values" {
+			t.Errorf("expected column %q, got %q", "values", name)
+		}
+		col, ok := rec.Column(0).(*array.Int64)
+		if !ok {
+			t.Fatalf("expected an Int64 column, got %T", rec.Column(0))
+		}
+		if want := []int64{-1, 2, -3, 4, 1 << 40, -(1 << 40)}; !reflect.DeepEqual(col.Int64Values(), want) {
+			t.Errorf("expected %v, got %v", want, col.Int64Values())
+		}
+		if col.NullN() != 0 {
+			t.Errorf("expected no nulls, got %d", col.NullN())
+		}
+	})
+
+	t.Run("big-endian float64", func(t *testing.T) {
+		fb := newFakeBucket(map[string][]byte{
+			"temps/.zarray": []byte(`{
+				"zarr_format": 2,
+				"shape": [3],
+				"chunks": [2],
+				"dtype": ">f8",
+				"compressor": null,
+				"fill_value": 0.5,
+				"order": "C"
+			}`),
+			"temps/0": encodeBE(t, []float64{1.5, -2}),
+		})
+		reader := openFakeArray(t, fb, "temps")
+
+		rec, err := reader.ToArrow(ctx)
+		if err != nil {
+			t.Fatalf("ToArrow failed: %v", err)
+		}
+		defer rec.Release()
+
+		if name := rec.ColumnName(0); name != "temps" {
+			t.Errorf("expected column %q, got %q", "temps", name)
+		}
+		if typ := rec.Column(0).DataType(); !arrow.TypeEqual(typ, arrow.PrimitiveTypes.Float64) {
+			t.Fatalf("expected a float64 column, got %v", typ)
+		}
+		if got := rec.Column(0).(*array.Float64).Float64Values(); !reflect.DeepEqual(got, []float64{1.5, -2, 0.5}) {
+			t.Errorf("expected [1.5 -2 0.5], got %v", got)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		if _, err := openSequential4x4(t).ToArrow(ctx); err == nil || !strings.Contains(err.Error(), "1-D") {
+			t.Errorf("expected a 2-D array to be rejected, got %v", err)
+		}
+
+		dir := t.TempDir()
+		writeArray(t, dir, `{
+			"zarr_format": 2,
+			"shape": [2],
+			"chunks": [2],
+			"dtype": "<c8",
+			"compressor": null,
+			"fill_value": null,
+			"order": "C"
+		}`, nil)
+		if _, err := openReader(t, dir).ToArrow(ctx); err == nil || !strings.Contains(err.Error(), "Arrow") {
+			t.Errorf("expected a complex dtype to be rejected, got %v", err)
+		}
+	})
+}
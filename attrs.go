@@ -0,0 +1,65 @@
+package zarr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sync"
+
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+)
+
+// attrsCache holds .zattrs once loaded. It is shared by a Reader and its
+// views, which all read the same stored array.
+type attrsCache struct {
+	mu    sync.Mutex
+	attrs map[string]any
+}
+
+// Attributes returns the user attributes stored in the array's .zattrs
+// file, such as units or coordinate names, or an empty map if the array has
+// none. The file is loaded on first use and cached; the returned map is a
+// shallow copy the caller may modify.
+func (r *Reader) Attributes(ctx context.Context) (map[string]any, error) {
+	if r.attrs == nil {
+		return loadAttributes(ctx, r.store.bucket, r.prefix)
+	}
+	r.attrs.mu.Lock()
+	defer r.attrs.mu.Unlock()
+	if r.attrs.attrs == nil {
+		attrs, err := loadAttributes(ctx, r.store.bucket, r.prefix)
+		if err != nil {
+			return nil, err
+		}
+		r.attrs.attrs = attrs
+	}
+	return maps.Clone(r.attrs.attrs), nil
+}
+
+// Attributes returns the user attributes stored in the group's .zattrs
+// file, or an empty map if the group has none.
+func (g *Group) Attributes(ctx context.Context) (map[string]any, error) {
+	return loadAttributes(ctx, g.store.bucket, g.prefix)
+}
+
+// loadAttributes reads and decodes the .zattrs file under prefix.
+func loadAttributes(ctx context.Context, bucket *blob.Bucket, prefix string) (map[string]any, error) {
+	data, err := bucket.ReadAll(ctx, prefix+".zattrs")
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return map[string]any{}, nil
+		}
+		return nil, fmt.Errorf("failed to read .zattrs: %w", err)
+	}
+	var attrs map[string]any
+	if err := json.Unmarshal(data, &attrs); err != nil {
+		return nil, fmt.Errorf("failed to decode .zattrs: %w", err)
+	}
+	if attrs == nil {
+		// A file holding just "null".
+		attrs = map[string]any{}
+	}
+	return attrs, nil
+}
@@ -0,0 +1,94 @@
+package zarr_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_Attributes(t *testing.T) {
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, sequential4x4, nil)
+	attrsPath := filepath.Join(dir, ".zattrs")
+	if err := os.WriteFile(attrsPath, []byte(`{"units": "kelvin", "scale": [1, 2]}`), 0644); err != nil {
+		t.Fatalf("failed to write .zattrs: %v", err)
+	}
+	reader := openReader(t, dir)
+	ctx := context.Background()
+
+	attrs, err := reader.Attributes(ctx)
+	if err != nil {
+		t.Fatalf("Attributes failed: %v", err)
+	}
+	if attrs["units"] != "kelvin" {
+		t.Errorf("expected units kelvin, got %v", attrs["units"])
+	}
+	if scale, ok := attrs["scale"].([]any); !ok || len(scale) != 2 || scale[1] != 2.0 {
+		t.Errorf("expected scale [1 2], got %v", attrs["scale"])
+	}
+	attrs["units"] = "celsius"
+
+	// Later calls, views included, are served from the cache.
+	if err := os.Remove(attrsPath); err != nil {
+		t.Fatal(err)
+	}
+	again, err := reader.Flip([]int{0}).Attributes(ctx)
+	if err != nil {
+		t.Fatalf("second Attributes failed: %v", err)
+	}
+	if again["units"] != "kelvin" {
+		t.Errorf("expected the cached units kelvin, got %v", again["units"])
+	}
+}
+
+func TestReader_AttributesMissing(t *testing.T) {
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, sequential4x4, nil)
+	reader := openReader(t, dir)
+
+	attrs, err := reader.Attributes(context.Background())
+	if err != nil {
+		t.Fatalf("Attributes failed: %v", err)
+	}
+	if attrs == nil || len(attrs) != 0 {
+		t.Errorf("expected an empty map, got %v", attrs)
+	}
+}
+
+func TestReader_AttributesInvalid(t *testing.T) {
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, sequential4x4, nil)
+	if err := os.WriteFile(filepath.Join(dir, ".zattrs"), []byte(`["not", "an", "object"]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reader := openReader(t, dir)
+
+	if _, err := reader.Attributes(context.Background()); err == nil {
+		t.Error("expected a non-object .zattrs to be rejected")
+	}
+}
+
+func TestGroup_Attributes(t *testing.T) {
+	dir := t.TempDir()
+	writeGroup(t, dir)
+	if err := os.WriteFile(filepath.Join(dir, ".zattrs"), []byte(`{"multiscales": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	group, err := zarr.OpenGroup(ctx, "file:///"+filepath.ToSlash(dir))
+	if err != nil {
+		t.Fatalf("OpenGroup failed: %v", err)
+	}
+	defer group.Close()
+
+	attrs, err := group.Attributes(ctx)
+	if err != nil {
+		t.Fatalf("Attributes failed: %v", err)
+	}
+	if _, ok := attrs["multiscales"]; !ok {
+		t.Errorf("expected a multiscales attribute, got %v", attrs)
+	}
+}
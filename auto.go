@@ -0,0 +1,94 @@
+package zarr
+
+import "context"
+
+// DefaultFullReadThreshold is the fraction of an array a region must cover
+// before ReadRegionAuto reads the whole array instead.
+const DefaultFullReadThreshold = 0.5
+
+// smallArrayBytes is the size below which ReadRegionAuto always reads the
+// whole array, since a few extra bytes cost less than extra requests.
+const smallArrayBytes = 1 << 20
+
+// WithFullReadThreshold returns a view whose ReadRegionAuto reads the whole
+// array once a region covers at least the given fraction of its elements.
+// A fraction above 1 disables the full read for all but small arrays.
+func (r *Reader) WithFullReadThreshold(fraction float64) *Reader {
+	v := r.view()
+	v.fullReadThreshold = fraction
+	return v
+}
+
+// ReadRegionAuto returns the same data as ReadRegion, but reads the whole
+// array with ReadFull and slices the region out of it when the region covers
+// most of the array (see WithFullReadThreshold) or the array is small. A
+// full read that would exceed the WithMaxReadBytes or WithMaxChunksPerRead
+// limits is never chosen.
+func (r *Reader) ReadRegionAuto(ctx context.Context, start, shape []int, opts ...ReadOption) ([]byte, error) {
+	if !r.preferFullRead(shape) {
+		return r.ReadRegion(ctx, start, shape, opts...)
+	}
+
+	viewShape := r.Shape()
+	if len(start) != len(viewShape) || len(shape) != len(viewShape) {
+		// Let ReadRegion report the invalid request.
+		return r.ReadRegion(ctx, start, shape, opts...)
+	}
+	for i := range viewShape {
+		if start[i] < 0 || shape[i] <= 0 || start[i]+shape[i] > viewShape[i] {
+			return r.ReadRegion(ctx, start, shape, opts...)
+		}
+	}
+
+	full, err := r.ReadFull(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(viewShape) == 0 {
+		return full, nil
+	}
+
+	itemSize, err := r.itemSize()
+	if err != nil {
+		return nil, err
+	}
+	outBytes, err := byteSize(shape, itemSize)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, outBytes)
+	copyND(out, strides(shape), make([]int, len(shape)), full, strides(viewShape), start, shape, itemSize)
+	return out, nil
+}
+
+// preferFullRead decides whether a region of the given shape is better
+// served by reading the whole array.
+func (r *Reader) preferFullRead(shape []int) bool {
+	itemSize, err := r.itemSize()
+	if err != nil {
+		return false
+	}
+	fullBytes, err := byteSize(r.meta.Shape, itemSize)
+	if err != nil || r.checkReadSize(fullBytes) != nil {
+		return false
+	}
+	if r.checkChunkCount(GridShape(r.meta.Shape, r.meta.Chunks)) != nil {
+		return false
+	}
+	if fullBytes <= smallArrayBytes {
+		return true
+	}
+
+	threshold := r.fullReadThreshold
+	if threshold == 0 {
+		threshold = DefaultFullReadThreshold
+	}
+	covered, total := 1.0, 1.0
+	for i := range r.meta.Shape {
+		if i < len(shape) {
+			covered *= float64(shape[i])
+		}
+		total *= float64(r.meta.Shape[i])
+	}
+	return total > 0 && covered/total >= threshold
+}
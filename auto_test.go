@@ -0,0 +1,91 @@
+package zarr_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestReader_ReadRegionAuto(t *testing.T) {
+	// 600x600 float32 is larger than the small-array cutoff.
+	values := make([]float32, 600*600)
+	for i := range values {
+		values[i] = float32(i)
+	}
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [600, 600],
+			"chunks": [100, 100],
+			"dtype": "<f4",
+			"compressor": null,
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+	})
+	for key, chunk := range chunkFloat32([]int{600, 600}, []int{100, 100}, values) {
+		fb.put(key, encodeLE(t, chunk))
+	}
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		start       []int
+		shape       []int
+		wantFullRun bool
+	}{
+		{"small region", []int{150, 250}, []int{100, 100}, false},
+		{"most of the array", []int{100, 100}, []int{500, 500}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := reader.ReadRegion(ctx, tt.start, tt.shape)
+			if err != nil {
+				t.Fatalf("ReadRegion failed: %v", err)
+			}
+			fb.resetReads()
+
+			got, err := reader.ReadRegionAuto(ctx, tt.start, tt.shape)
+			if err != nil {
+				t.Fatalf("ReadRegionAuto failed: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Error("ReadRegionAuto returned different data from ReadRegion")
+			}
+			if full := len(fb.readsOf("0.0")) > 0; full != tt.wantFullRun {
+				t.Errorf("expected full read %v, got %v", tt.wantFullRun, full)
+			}
+		})
+	}
+
+	// Raising the threshold keeps the region path.
+	fb.resetReads()
+	if _, err := reader.WithFullReadThreshold(0.9).ReadRegionAuto(ctx, []int{100, 100}, []int{500, 500}); err != nil {
+		t.Fatalf("ReadRegionAuto failed: %v", err)
+	}
+	if len(fb.readsOf("0.0")) > 0 {
+		t.Error("expected a region read with a 0.9 threshold")
+	}
+}
+
+func TestReader_ReadRegionAutoView(t *testing.T) {
+	reader := openSequential4x4(t)
+	view := reader.Transpose([]int{1, 0}).Flip([]int{1})
+	ctx := context.Background()
+
+	want, err := view.ReadRegion(ctx, []int{1, 0}, []int{2, 3})
+	if err != nil {
+		t.Fatalf("ReadRegion failed: %v", err)
+	}
+	got, err := view.ReadRegionAuto(ctx, []int{1, 0}, []int{2, 3})
+	if err != nil {
+		t.Fatalf("ReadRegionAuto failed: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("expected %v, got %v", decodeFloat32(want), decodeFloat32(got))
+	}
+	if _, err := view.ReadRegionAuto(ctx, []int{3, 3}, []int{2, 2}); err == nil {
+		t.Error("expected an error for an out-of-bounds region")
+	}
+}
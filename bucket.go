@@ -0,0 +1,141 @@
+package zarr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"gocloud.dev/blob"
+	"golang.org/x/sync/singleflight"
+)
+
+// SharedBucket is a blob bucket that can be shared by several Readers, e.g.
+// all arrays of a group or all levels of a multiscale pyramid.
+//
+// The bucket is reference-counted: the SharedBucket itself holds one
+// reference and every Reader opened from it holds another. The underlying
+// bucket is closed once all of them have been closed.
+type SharedBucket struct {
+	bucket *blob.Bucket
+
+	mu     sync.Mutex
+	refs   int
+	closed bool
+}
+
+// OpenSharedBucket opens the bucket at the given gocloud URL for sharing.
+func OpenSharedBucket(ctx context.Context, url string) (*SharedBucket, error) {
+	bucket, err := blob.OpenBucket(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bucket: %w", err)
+	}
+	return NewSharedBucket(bucket), nil
+}
+
+// NewSharedBucket wraps an already opened bucket. Ownership of the bucket is
+// transferred to the SharedBucket.
+func NewSharedBucket(bucket *blob.Bucket) *SharedBucket {
+	return &SharedBucket{bucket: bucket, refs: 1}
+}
+
+// Bucket returns the underlying bucket.
+func (s *SharedBucket) Bucket() *blob.Bucket {
+	return s.bucket
+}
+
+// OpenArray opens the array stored under the given key prefix (e.g.
+// "group/array", or "" for the bucket root). The returned Reader holds its
+// own reference to the bucket.
+func (s *SharedBucket) OpenArray(ctx context.Context, path string) (*Reader, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+
+	r := &Reader{store: s, ref: newBucketRef(s), prefix: keyPrefix(path), attrs: &attrsCache{}, dedup: &dedupFlag{}, flights: &singleflight.Group{}}
+	meta, err := r.loadMetadata(ctx)
+	if err != nil {
+		r.ref.release()
+		return nil, err
+	}
+	r.meta = meta
+	r.encoding = ChunkEncoding{Separator: meta.DimensionSeparator}
+	if r.fill, err = encodeFill(meta.DType, meta.FillValue); err != nil {
+		r.ref.release()
+		return nil, fmt.Errorf("failed to parse fill_value: %w", err)
+	}
+	if itemSize, err := r.itemSize(); err == nil {
+		if n, err := byteSize(meta.Chunks, itemSize); err == nil {
+			r.buffers = newBufferPool(n)
+		}
+	}
+	return r, nil
+}
+
+// Close releases the reference held by the SharedBucket itself. Readers
+// opened from it stay usable until they are closed.
+func (s *SharedBucket) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mu.Unlock()
+	return s.release()
+}
+
+func (s *SharedBucket) acquire() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.refs == 0 {
+		return fmt.Errorf("shared bucket is closed")
+	}
+	s.refs++
+	return nil
+}
+
+func (s *SharedBucket) release() error {
+	s.mu.Lock()
+	if s.refs == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	s.refs--
+	last := s.refs == 0
+	s.mu.Unlock()
+	if last {
+		return s.bucket.Close()
+	}
+	return nil
+}
+
+// bucketRef is the reference a Reader, Writer or Group holds on its
+// SharedBucket. Releasing it more than once is a no-op, so that closing a
+// handle twice cannot drop a reference held by another handle.
+type bucketRef struct {
+	once  sync.Once
+	store *SharedBucket
+	err   error
+}
+
+func newBucketRef(s *SharedBucket) *bucketRef {
+	return &bucketRef{store: s}
+}
+
+func (ref *bucketRef) release() error {
+	ref.once.Do(func() {
+		ref.err = ref.store.release()
+	})
+	return ref.err
+}
+
+// keyPrefix normalizes a path inside a bucket to a key prefix that is either
+// empty or ends with a slash.
+func keyPrefix(path string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return ""
+	}
+	return path + "/"
+}
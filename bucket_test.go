@@ -0,0 +1,196 @@
+package zarr_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/fileblob"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+const vector4 = `{
+	"zarr_format": 2,
+	"shape": [4],
+	"chunks": [2],
+	"dtype": "<f4",
+	"compressor": null,
+	"fill_value": 0.0,
+	"order": "C"
+}`
+
+func TestSharedBucket_IndependentReaders(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFloat32Array(t, filepath.Join(tempDir, "a"), vector4, map[string][]float32{
+		"0": {1, 2}, "1": {3, 4},
+	})
+	writeFloat32Array(t, filepath.Join(tempDir, "group", "b"), vector4, map[string][]float32{
+		"0": {10, 20}, "1": {30, 40},
+	})
+
+	ctx := context.Background()
+	store, err := zarr.OpenSharedBucket(ctx, "file:///"+filepath.ToSlash(tempDir))
+	if err != nil {
+		t.Fatalf("OpenSharedBucket failed: %v", err)
+	}
+
+	a, err := store.OpenArray(ctx, "a")
+	if err != nil {
+		t.Fatalf("OpenArray(a) failed: %v", err)
+	}
+	b, err := store.OpenArray(ctx, "/group/b/")
+	if err != nil {
+		t.Fatalf("OpenArray(group/b) failed: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("closing the shared bucket failed: %v", err)
+	}
+
+	data, err := a.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull(a) failed: %v", err)
+	}
+	if got := decodeFloat32(data); got[0] != 1 || got[3] != 4 {
+		t.Errorf("unexpected data for a: %v", got)
+	}
+
+	// Closing one reader must not break its sibling.
+	if err := a.Close(); err != nil {
+		t.Fatalf("closing a failed: %v", err)
+	}
+
+	data, err = b.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull(b) after closing a failed: %v", err)
+	}
+	if got := decodeFloat32(data); got[0] != 10 || got[3] != 40 {
+		t.Errorf("unexpected data for b: %v", got)
+	}
+
+	if err := b.Close(); err != nil {
+		t.Fatalf("closing b failed: %v", err)
+	}
+
+	if _, err := store.OpenArray(ctx, "a"); err == nil {
+		t.Error("expected OpenArray to fail once every reference is closed")
+	}
+}
+
+func TestSharedBucket_DoubleClose(t *testing.T) {
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(vector4),
+		".zgroup": []byte(`{"zarr_format": 2}`),
+		"0":       encodeLE(t, []float32{1, 2}),
+	})
+	store := zarr.NewSharedBucket(blob.NewBucket(fb))
+	ctx := context.Background()
+
+	a, err := store.OpenArray(ctx, "")
+	if err != nil {
+		t.Fatalf("OpenArray(a) failed: %v", err)
+	}
+	b, err := store.OpenArray(ctx, "")
+	if err != nil {
+		t.Fatalf("OpenArray(b) failed: %v", err)
+	}
+	g, err := store.OpenGroup(ctx, "")
+	if err != nil {
+		t.Fatalf("OpenGroup failed: %v", err)
+	}
+	store.Close()
+	store.Close()
+
+	// Each handle releases its reference once, however often it is closed.
+	for i := 0; i < 3; i++ {
+		if err := a.Close(); err != nil {
+			t.Fatalf("closing a failed: %v", err)
+		}
+		if err := g.Close(); err != nil {
+			t.Fatalf("closing the group failed: %v", err)
+		}
+	}
+	data, err := b.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull(b) after closing a twice failed: %v", err)
+	}
+	if got := decodeFloat32(data); got[0] != 1 || got[1] != 2 {
+		t.Errorf("unexpected data for b: %v", got)
+	}
+
+	b.Close()
+	b.Close()
+	if _, err := store.OpenArray(ctx, ""); err == nil {
+		t.Error("expected OpenArray to fail once every reference is closed")
+	}
+}
+
+func TestWriter_DoubleClose(t *testing.T) {
+	store := zarr.NewSharedBucket(blob.NewBucket(newFakeBucket(nil)))
+	ctx := context.Background()
+	meta := &zarr.Metadata{Shape: []int{4}, Chunks: []int{2}, DType: "<f4"}
+
+	w, err := store.CreateArray(ctx, "", meta)
+	if err != nil {
+		t.Fatalf("CreateArray failed: %v", err)
+	}
+	r, err := store.OpenArray(ctx, "")
+	if err != nil {
+		t.Fatalf("OpenArray failed: %v", err)
+	}
+	defer r.Close()
+	store.Close()
+
+	w.Close()
+	w.Close()
+	if _, err := r.ReadFull(ctx); err != nil {
+		t.Errorf("ReadFull after closing the writer twice failed: %v", err)
+	}
+}
+
+// slowScheme is a URL scheme serving fake buckets whose reads block until
+// their context is done.
+const slowScheme = "slowfake"
+
+type slowOpener struct{}
+
+func (slowOpener) OpenBucketURL(ctx context.Context, u *url.URL) (*blob.Bucket, error) {
+	fb := newFakeBucket(map[string][]byte{".zarray": []byte(vector4)})
+	fb.beforeRead = func(ctx context.Context, key string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return blob.NewBucket(fb), nil
+}
+
+func init() {
+	blob.DefaultURLMux().RegisterBucket(slowScheme, slowOpener{})
+}
+
+func TestNewReaderTimeout(t *testing.T) {
+	start := time.Now()
+	_, err := zarr.NewReaderTimeout(context.Background(), slowScheme+"://bucket", 50*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("open took %v despite the timeout", elapsed)
+	}
+
+	// A responsive store opens normally, and reads are not bound by the
+	// open timeout.
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, vector4, map[string][]float32{"0": {1, 2}, "1": {3, 4}})
+	reader, err := zarr.NewReaderTimeout(context.Background(), "file:///"+filepath.ToSlash(dir), time.Second)
+	if err != nil {
+		t.Fatalf("NewReaderTimeout failed: %v", err)
+	}
+	defer reader.Close()
+	if _, err := reader.ReadFull(context.Background()); err != nil {
+		t.Errorf("ReadFull after a timed open failed: %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package zarr
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"sync"
+)
+
+// Cache stores decompressed chunks between reads, keyed by the chunk's key in
+// the bucket, e.g. "group/array/0.1". A cache may be shared by several
+// readers of the same bucket, and must be safe for concurrent use. Readers
+// never modify the slices they pass to Add or receive from Get.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Add(key string, data []byte)
+}
+
+// LRUCache is a Cache holding up to a fixed number of chunks, evicting the
+// least recently used one when full.
+type LRUCache struct {
+	mu      sync.Mutex
+	max     int
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key  string
+	data []byte
+}
+
+// NewLRUCache returns an LRUCache holding up to maxChunks chunks.
+func NewLRUCache(maxChunks int) *LRUCache {
+	return &LRUCache{max: maxChunks, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Get returns the chunk cached under key and marks it as recently used.
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry).data, true
+}
+
+// Add caches data under key, evicting the least recently used chunk if the
+// cache is full. A cache of size 0 or less holds nothing.
+func (c *LRUCache) Add(key string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.max <= 0 {
+		return
+	}
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry).data = data
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, data: data})
+	if c.order.Len() > c.max {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of cached chunks.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// NewReaderWithCache opens a reader like NewReader whose chunk reads go
+// through an LRU cache of up to maxChunks decompressed chunks.
+func NewReaderWithCache(ctx context.Context, path string, maxChunks int) (*Reader, error) {
+	reader, err := NewReader(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	reader.cache = NewLRUCache(maxChunks)
+	return reader, nil
+}
+
+// WithCache returns a view of the array that looks chunks up in c before
+// fetching them, and adds the chunks it fetches to c. Cached chunks skip both
+// the download and the decompression. A nil c disables caching.
+func (r *Reader) WithCache(c Cache) *Reader {
+	v := r.view()
+	v.cache = c
+	return v
+}
+
+// cacheGet returns the decompressed chunk cached under the bucket key.
+func (r *Reader) cacheGet(key string) ([]byte, bool) {
+	if r.cache == nil {
+		return nil, false
+	}
+	return r.cache.Get(key)
+}
+
+// cacheAdd caches a copy of a decompressed chunk, since the reader recycles
+// the buffers it decodes into.
+func (r *Reader) cacheAdd(key string, data []byte) {
+	if r.cache != nil {
+		r.cache.Add(key, bytes.Clone(data))
+	}
+}
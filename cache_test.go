@@ -0,0 +1,123 @@
+package zarr_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_WithCache(t *testing.T) {
+	fb, _ := gzipArray(t, 16, 4)
+	cache := zarr.NewLRUCache(4)
+	reader := openFake(t, fb).WithCache(cache)
+	ctx := context.Background()
+
+	first, err := reader.ReadChunk(ctx, []int{1, 1})
+	if err != nil {
+		t.Fatalf("ReadChunk failed: %v", err)
+	}
+	want := bytes.Clone(first)
+	for i := range first {
+		first[i] = 0xff
+	}
+	for i := 0; i < 3; i++ {
+		again, err := reader.ReadChunk(ctx, []int{1, 1})
+		if err != nil {
+			t.Fatalf("ReadChunk failed: %v", err)
+		}
+		if !bytes.Equal(again, want) {
+			t.Fatal("cached chunk changed after mutating an earlier result")
+		}
+	}
+	region, err := reader.ReadRegion(ctx, []int{4, 4}, []int{4, 4})
+	if err != nil {
+		t.Fatalf("ReadRegion failed: %v", err)
+	}
+	if !bytes.Equal(region, want) {
+		t.Error("ReadRegion over a cached chunk differs from ReadChunk")
+	}
+	if n := len(fb.readsOf("1.1")); n != 1 {
+		t.Errorf("expected chunk 1.1 to be fetched once, got %d fetches", n)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("expected one cached chunk, got %d", cache.Len())
+	}
+}
+
+func TestReader_WithCacheConcurrent(t *testing.T) {
+	fb, _ := gzipArray(t, 32, 4)
+	reader := openFake(t, fb).
+		WithCache(zarr.NewLRUCache(64)).
+		WithOptions(zarr.ReaderOptions{Concurrency: 8})
+	ctx := context.Background()
+
+	want, err := reader.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+	fb.resetReads()
+	got, err := reader.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("cached ReadFull failed: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("cached ReadFull differs from the first read")
+	}
+	if n := fb.readCount(); n != 0 {
+		t.Errorf("expected a fully cached read to skip the bucket, got %d reads", n)
+	}
+}
+
+func TestLRUCache_Eviction(t *testing.T) {
+	cache := zarr.NewLRUCache(2)
+	cache.Add("a", []byte{1})
+	cache.Add("b", []byte{2})
+	cache.Get("a")
+	cache.Add("c", []byte{3})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+	for key, want := range map[string]byte{"a": 1, "c": 3} {
+		if data, ok := cache.Get(key); !ok || data[0] != want {
+			t.Errorf("expected %s to hold %d, got %v, %v", key, want, data, ok)
+		}
+	}
+
+	empty := zarr.NewLRUCache(0)
+	empty.Add("a", []byte{1})
+	if empty.Len() != 0 {
+		t.Error("expected a zero-size cache to hold nothing")
+	}
+}
+
+func TestNewReaderWithCache(t *testing.T) {
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, sequential4x4, map[string][]float32{
+		"0.0": {0, 1, 4, 5},
+	})
+	reader, err := zarr.NewReaderWithCache(context.Background(), "file:///"+filepath.ToSlash(dir), 8)
+	if err != nil {
+		t.Fatalf("NewReaderWithCache failed: %v", err)
+	}
+	defer reader.Close()
+
+	for i := 0; i < 2; i++ {
+		data, err := reader.ReadRegion(context.Background(), []int{0, 1}, []int{2, 1})
+		if err != nil {
+			t.Fatalf("ReadRegion failed: %v", err)
+		}
+		if got := decodeFloat32(data); got[0] != 1 || got[1] != 5 {
+			t.Errorf("read %d: expected [1 5], got %v", i, got)
+		}
+		// Uncompressed chunks are fetched whole rather than by range once
+		// they can be cached, so the second read needs no chunk file.
+		if err := os.Remove(filepath.Join(dir, "0.0")); err != nil && i == 0 {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package zarr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gocloud.dev/gcerrors"
+)
+
+// VerifyChecksums audits stored chunks against a checksum manifest and
+// returns the keys, sorted, of the chunks whose bytes no longer match. The
+// manifest is a JSON object stored at manifestPath relative to the array
+// (conventionally ".zchecksum") mapping chunk keys to the hex SHA-256 of the
+// chunk as stored, optionally prefixed with "sha256:". Chunks listed in the
+// manifest but missing from the store count as mismatches. Chunks are hashed
+// as stored, without decompressing them.
+func (r *Reader) VerifyChecksums(ctx context.Context, manifestPath string) ([]string, error) {
+	manifest, err := r.loadManifest(ctx, manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(manifest))
+	for key := range manifest {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var mismatched []string
+	for _, key := range keys {
+		want, err := hex.DecodeString(strings.TrimPrefix(manifest[key], "sha256:"))
+		if err != nil || len(want) != sha256.Size {
+			return nil, fmt.Errorf("invalid checksum for %s in manifest: %q", key, manifest[key])
+		}
+		got, err := r.hashObject(ctx, key)
+		if err != nil {
+			if gcerrors.Code(err) == gcerrors.NotFound {
+				mismatched = append(mismatched, key)
+				continue
+			}
+			return nil, err
+		}
+		if string(got) != string(want) {
+			mismatched = append(mismatched, key)
+		}
+	}
+	return mismatched, nil
+}
+
+// loadManifest reads a JSON checksum manifest stored relative to the array.
+func (r *Reader) loadManifest(ctx context.Context, manifestPath string) (map[string]string, error) {
+	reader, err := r.store.bucket.NewReader(ctx, r.key(manifestPath), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checksum manifest %s: %w", manifestPath, err)
+	}
+	defer reader.Close()
+
+	var manifest map[string]string
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode checksum manifest %s: %w", manifestPath, err)
+	}
+	return manifest, nil
+}
+
+// hashObject returns the SHA-256 of an object of the array as stored. Errors
+// opening the object are returned unwrapped so callers can test for
+// NotFound.
+func (r *Reader) hashObject(ctx context.Context, key string) ([]byte, error) {
+	reader, err := r.store.bucket.NewReader(ctx, r.key(key), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, reader); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	return h.Sum(nil), nil
+}
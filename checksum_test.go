@@ -0,0 +1,72 @@
+package zarr_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestReader_VerifyChecksums(t *testing.T) {
+	chunks := map[string][]byte{
+		"0": encodeLE(t, []float32{1, 2}),
+		"1": encodeLE(t, []float32{3, 4}),
+		"2": encodeLE(t, []float32{5, 6}),
+	}
+	manifest := map[string]string{}
+	for key, data := range chunks {
+		sum := sha256.Sum256(data)
+		manifest[key] = hex.EncodeToString(sum[:])
+	}
+	manifest["2"] = "sha256:" + manifest["2"]
+	manifest["3"] = manifest["0"] // listed but never written
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatalf("failed to encode manifest: %v", err)
+	}
+
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [8],
+			"chunks": [2],
+			"dtype": "<f4",
+			"compressor": null,
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+		".zchecksum": manifestJSON,
+	})
+	for key, data := range chunks {
+		fb.put(key, data)
+	}
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	bad, err := reader.VerifyChecksums(ctx, ".zchecksum")
+	if err != nil {
+		t.Fatalf("VerifyChecksums failed: %v", err)
+	}
+	if want := []string{"3"}; !slices.Equal(bad, want) {
+		t.Errorf("expected mismatches %v before corruption, got %v", want, bad)
+	}
+
+	// Flip one byte of a stored chunk.
+	corrupted := append([]byte(nil), chunks["1"]...)
+	corrupted[0] ^= 0xff
+	fb.put("1", corrupted)
+
+	bad, err = reader.VerifyChecksums(ctx, ".zchecksum")
+	if err != nil {
+		t.Fatalf("VerifyChecksums failed: %v", err)
+	}
+	if want := []string{"1", "3"}; !slices.Equal(bad, want) {
+		t.Errorf("expected mismatches %v, got %v", want, bad)
+	}
+
+	if _, err := reader.VerifyChecksums(ctx, "missing.json"); err == nil {
+		t.Error("expected an error for a missing manifest")
+	}
+}
@@ -1,6 +1,8 @@
 package zarr
 
 import (
+	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -21,22 +23,155 @@ func GridShape(shape, chunks []int) []int {
 // ChunkKey generates the key for a chunk given its indices and a separator.
 // For Zarr V2, the separator is typically ".".
 // Example: indices=[1, 4], separator="." -> "1.4"
-// For 0D arrays (empty indices), it returns "0" per the Zarr spec.
+// For 0D arrays (empty indices), it returns "0" per the Zarr spec. It is
+// shorthand for ChunkEncoding{Separator: separator}.Encode(indices), so an
+// empty separator means ".".
 func ChunkKey(indices []int, separator string) string {
-	if len(indices) == 0 {
-		return "0"
+	return ChunkEncoding{Separator: separator}.Encode(indices)
+}
+
+// forEachChunk calls fn with the coordinates of every chunk between first and
+// last inclusive, in the given order. The coordinates slice is only valid for
+// the duration of the call. Iteration stops at the first error.
+func forEachChunk(first, last []int, order ChunkOrder, fn func(coords []int) error) error {
+	if order == ChunkOrderMorton {
+		return forEachChunkMorton(first, last, fn)
+	}
+
+	coords := make([]int, len(first))
+	var iterate func(dim int) error
+	iterate = func(dim int) error {
+		if dim == len(first) {
+			return fn(coords)
+		}
+		for i := first[dim]; i <= last[dim]; i++ {
+			coords[dim] = i
+			if err := iterate(dim + 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return iterate(0)
+}
+
+// forEachChunkMorton visits the chunks between first and last in Z-order of
+// their offsets from first.
+func forEachChunkMorton(first, last []int, fn func(coords []int) error) error {
+	var all [][]int
+	if err := forEachChunk(first, last, ChunkOrderC, func(coords []int) error {
+		all = append(all, slices.Clone(coords))
+		return nil
+	}); err != nil {
+		return err
 	}
 
-	if len(indices) == 1 {
-		return strconv.Itoa(indices[0])
+	slices.SortFunc(all, func(a, b []int) int {
+		// The dimension whose coordinates differ in the most significant bit
+		// decides the order along the curve.
+		dim, msb := 0, 0
+		for i := range a {
+			if x := (a[i] - first[i]) ^ (b[i] - first[i]); lessMSB(msb, x) {
+				dim, msb = i, x
+			}
+		}
+		return a[dim] - b[dim]
+	})
+
+	for _, coords := range all {
+		if err := fn(coords); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lessMSB reports whether the most significant set bit of x is lower than
+// that of y.
+func lessMSB(x, y int) bool {
+	return x < y && x < x^y
+}
+
+// ChunkEncoding describes how chunk coordinates map to object keys relative
+// to the array. The zero value, like Zarr V2's default, joins coordinates
+// with "." and no prefix; V2's "/" dimension_separator and V3's default
+// "c/"-prefixed keys are expressed with Separator and Prefix.
+type ChunkEncoding struct {
+	// Prefix is prepended to every key, e.g. "c/".
+	Prefix string
+	// Separator joins coordinates; empty means ".".
+	Separator string
+	// ZeroPadWidth pads each coordinate with leading zeros to this many
+	// digits when positive.
+	ZeroPadWidth int
+	// ReverseAxes writes coordinates last axis first, for stores whose key
+	// "1.0" means column 1, row 0. It only affects keys, not the order of
+	// elements within a chunk.
+	ReverseAxes bool
+}
+
+func (e ChunkEncoding) separator() string {
+	if e.Separator == "" {
+		return "."
+	}
+	return e.Separator
+}
+
+// Encode returns the key of the chunk at coords. A 0-dimensional array has a
+// single chunk keyed "0".
+func (e ChunkEncoding) Encode(coords []int) string {
+	if len(coords) == 0 {
+		return e.Prefix + "0"
 	}
 
 	var sb strings.Builder
-	for i, idx := range indices {
+	sb.WriteString(e.Prefix)
+	for i := range coords {
 		if i > 0 {
-			sb.WriteString(separator)
+			sb.WriteString(e.separator())
+		}
+		c := coords[i]
+		if e.ReverseAxes {
+			c = coords[len(coords)-1-i]
 		}
-		sb.WriteString(strconv.Itoa(idx))
+		sb.WriteString(e.formatCoord(c))
 	}
 	return sb.String()
 }
+
+// Decode parses a key produced by Encode back into chunk coordinates. It
+// reports false for keys that are not in the encoding's canonical form, such
+// as metadata files or coordinates with the wrong padding.
+func (e ChunkEncoding) Decode(key string) ([]int, bool) {
+	rest, ok := strings.CutPrefix(key, e.Prefix)
+	if !ok || rest == "" {
+		return nil, false
+	}
+
+	parts := strings.Split(rest, e.separator())
+	coords := make([]int, len(parts))
+	for i, p := range parts {
+		c, err := strconv.Atoi(p)
+		if err != nil || c < 0 || e.formatCoord(c) != p {
+			return nil, false
+		}
+		coords[i] = c
+	}
+	if e.ReverseAxes {
+		slices.Reverse(coords)
+	}
+	return coords, true
+}
+
+func (e ChunkEncoding) formatCoord(c int) string {
+	if e.ZeroPadWidth > 0 {
+		return fmt.Sprintf("%0*d", e.ZeroPadWidth, c)
+	}
+	return strconv.Itoa(c)
+}
+
+// nested reports whether keys may contain "/", so that listing the array
+// needs to descend into sub-directories.
+func (e ChunkEncoding) nested() bool {
+	return strings.Contains(e.Prefix, "/") || strings.Contains(e.separator(), "/")
+}
@@ -1,6 +1,9 @@
 package zarr_test
 
 import (
+	"context"
+	"encoding/binary"
+	"math"
 	"reflect"
 	"testing"
 
@@ -73,6 +76,12 @@ func TestChunkKey(t *testing.T) {
 			sep:      ".",
 			expected: "0",
 		},
+		{
+			name:     "Indices [3, 1], empty sep",
+			indices:  []int{3, 1},
+			sep:      "",
+			expected: "3.1",
+		},
 	}
 
 	for _, tt := range tests {
@@ -84,3 +93,90 @@ func TestChunkKey(t *testing.T) {
 		})
 	}
 }
+
+func TestChunkEncoding(t *testing.T) {
+	tests := []struct {
+		name   string
+		enc    zarr.ChunkEncoding
+		coords []int
+		key    string
+	}{
+		{"default", zarr.ChunkEncoding{}, []int{1, 20}, "1.20"},
+		{"dot", zarr.ChunkEncoding{Separator: "."}, []int{0, 2, 5}, "0.2.5"},
+		{"slash", zarr.ChunkEncoding{Separator: "/"}, []int{0, 2, 5}, "0/2/5"},
+		{"prefix", zarr.ChunkEncoding{Prefix: "c/", Separator: "/"}, []int{3, 4}, "c/3/4"},
+		{"padded", zarr.ChunkEncoding{ZeroPadWidth: 3}, []int{7, 1234}, "007.1234"},
+		{"prefix and padding", zarr.ChunkEncoding{Prefix: "c.", ZeroPadWidth: 2}, []int{1, 2}, "c.01.02"},
+		{"reversed", zarr.ChunkEncoding{ReverseAxes: true}, []int{1, 2, 3}, "3.2.1"},
+		{"reversed nested padded", zarr.ChunkEncoding{Prefix: "c/", Separator: "/", ZeroPadWidth: 2, ReverseAxes: true}, []int{4, 10}, "c/10/04"},
+		{"0d", zarr.ChunkEncoding{}, []int{}, "0"},
+		{"0d prefix", zarr.ChunkEncoding{Prefix: "c/"}, []int{}, "c/0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.enc.Encode(tt.coords); got != tt.key {
+				t.Errorf("Encode(%v): expected %q, got %q", tt.coords, tt.key, got)
+			}
+			if len(tt.coords) == 0 {
+				return
+			}
+			got, ok := tt.enc.Decode(tt.key)
+			if !ok || !reflect.DeepEqual(got, tt.coords) {
+				t.Errorf("Decode(%q): expected %v, got %v (ok=%v)", tt.key, tt.coords, got, ok)
+			}
+		})
+	}
+
+	rejects := []struct {
+		enc zarr.ChunkEncoding
+		key string
+	}{
+		{zarr.ChunkEncoding{}, ".zarray"},
+		{zarr.ChunkEncoding{}, "1.x"},
+		{zarr.ChunkEncoding{}, "01.2"},
+		{zarr.ChunkEncoding{}, "1.-2"},
+		{zarr.ChunkEncoding{Prefix: "c/"}, "1.2"},
+		{zarr.ChunkEncoding{ZeroPadWidth: 3}, "7.001"},
+	}
+	for _, tt := range rejects {
+		if coords, ok := tt.enc.Decode(tt.key); ok {
+			t.Errorf("Decode(%q) with %+v: expected rejection, got %v", tt.key, tt.enc, coords)
+		}
+	}
+}
+
+func TestReader_ScalarChunkKey(t *testing.T) {
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [],
+			"chunks": [],
+			"dtype": "<f8",
+			"compressor": null,
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+		"0": encodeLE(t, []float64{2.5}),
+	})
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	for name, read := range map[string]func() ([]byte, error){
+		"ReadChunk":  func() ([]byte, error) { return reader.ReadChunk(ctx, []int{}) },
+		"ReadRegion": func() ([]byte, error) { return reader.ReadRegion(ctx, []int{}, []int{}) },
+		"ReadFull":   func() ([]byte, error) { return reader.ReadFull(ctx) },
+	} {
+		fb.resetReads()
+		data, err := read()
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if got := math.Float64frombits(binary.LittleEndian.Uint64(data)); got != 2.5 {
+			t.Errorf("%s: expected 2.5, got %v", name, got)
+		}
+		if n := len(fb.readsOf("0")); n != 1 || fb.readCount() != 1 {
+			t.Errorf("%s: expected a single read of key \"0\", got %d of %d", name, n, fb.readCount())
+		}
+	}
+}
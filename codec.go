@@ -0,0 +1,384 @@
+package zarr
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log/slog"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/mrjoshuak/go-blosc"
+	"github.com/pierrec/lz4/v4"
+)
+
+// decompress decodes raw chunk bytes with the array's compressor, preferring
+// decoders registered on the reader over the built-in ones. dst, if not nil,
+// is a buffer the built-in codecs may decode into, see decodesInto. A read
+// whose context is already done skips the decoding, so that cancelled
+// concurrent reads stop spending CPU on chunks nobody will use.
+func (r *Reader) decompress(ctx context.Context, key string, data, dst []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if cfg := r.meta.Compressor; cfg != nil {
+		_, custom := r.decompressors[cfg.ID]
+		r.debug(ctx, "decompressing chunk", slog.String("key", key), slog.String("codec", cfg.ID), slog.Bool("custom", custom))
+		if fn, ok := r.decompressors[cfg.ID]; ok {
+			out, err := recoverDecode(cfg.ID, fn, data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decompress %s data: %w", cfg.ID, err)
+			}
+			return out, nil
+		}
+		switch cfg.ID {
+		case "blosc":
+			if err := r.checkBloscSize(data); err != nil {
+				return nil, err
+			}
+		case "lz4":
+			if err := r.checkLZ4Size(data); err != nil {
+				return nil, err
+			}
+		}
+	}
+	limit, err := r.chunkBytes()
+	if err != nil {
+		limit = -1
+	}
+	return decompress(data, r.meta.Compressor, dst, limit)
+}
+
+// checkBloscSize rejects blosc chunks whose header claims more bytes than a
+// chunk can hold, before the decoder allocates room for them.
+func (r *Reader) checkBloscSize(data []byte) error {
+	n, err := blosc.GetDecompressedSize(data)
+	if err != nil {
+		// Leave reporting malformed headers to the decoder.
+		return nil
+	}
+	itemSize, err := r.itemSize()
+	if err != nil {
+		return nil
+	}
+	chunkBytes, err := byteSize(r.meta.Chunks, itemSize)
+	if err != nil {
+		return nil
+	}
+	if n > chunkBytes {
+		return fmt.Errorf("%w: blosc header claims %d bytes, chunk holds %d", ErrChunkCorrupt, n, chunkBytes)
+	}
+	return nil
+}
+
+// checkLZ4Size rejects lz4 chunks whose length header claims more bytes than
+// a chunk can hold, like checkBloscSize.
+func (r *Reader) checkLZ4Size(data []byte) error {
+	if len(data) < 4 {
+		// Leave reporting truncated headers to the decoder.
+		return nil
+	}
+	n := int64(int32(binary.LittleEndian.Uint32(data)))
+	itemSize, err := r.itemSize()
+	if err != nil {
+		return nil
+	}
+	chunkBytes, err := byteSize(r.meta.Chunks, itemSize)
+	if err != nil {
+		return nil
+	}
+	if n > int64(chunkBytes) {
+		return fmt.Errorf("%w: lz4 header claims %d bytes, chunk holds %d", ErrChunkCorrupt, n, chunkBytes)
+	}
+	return nil
+}
+
+// recoverDecode runs a decoder, turning a panic into an ErrChunkCorrupt
+// error, so that one malformed chunk cannot crash the process.
+func recoverDecode(id string, fn func([]byte) ([]byte, error), data []byte) (out []byte, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			out, err = nil, fmt.Errorf("%w: %s decoder panicked: %v", ErrChunkCorrupt, id, p)
+		}
+	}()
+	return fn(data)
+}
+
+// decodesInto reports whether the built-in codec for cfg makes use of the dst
+// buffer passed to decompress.
+func decodesInto(cfg *CompressorConfig) bool {
+	if cfg == nil {
+		return false
+	}
+	switch cfg.ID {
+	case "zlib", "gzip", "zstd", "lz4", "bz2", "brotli":
+		return true
+	}
+	return false
+}
+
+// decompress decodes raw chunk bytes with the given compressor. A nil config
+// means the chunk is stored uncompressed. Codecs for which decodesInto is
+// true reuse the capacity of dst, if any, for their output. The bz2 and
+// brotli streams carry no decoded size to check up front, so their output is
+// cut off past limit bytes instead, unless limit is negative.
+func decompress(data []byte, cfg *CompressorConfig, dst []byte, limit int) ([]byte, error) {
+	if cfg == nil {
+		return data, nil
+	}
+
+	switch cfg.ID {
+	case "blosc":
+		out, err := recoverDecode("blosc", blosc.Decompress, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress blosc data: %w", err)
+		}
+		return out, nil
+	case "zlib", "gzip":
+		return inflate(data, dst)
+	case "zstd":
+		dec, err := zstdDecoder()
+		if err != nil {
+			return nil, fmt.Errorf("failed to init zstd decoder: %w", err)
+		}
+		out, err := dec.DecodeAll(data, dst[:0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress zstd data: %w", err)
+		}
+		return out, nil
+	case "lz4":
+		return decodeLZ4(data, dst)
+	case "bz2":
+		out, err := readBounded("bz2", bzip2.NewReader(bytes.NewReader(data)), dst, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress bz2 data: %w", err)
+		}
+		return out, nil
+	case "crc32c":
+		return stripCRC32C(data)
+	case "brotli":
+		out, err := readBounded("brotli", brotli.NewReader(bytes.NewReader(data)), dst, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress brotli data: %w", err)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported compressor: %s", cfg.ID)
+	}
+}
+
+// zstdDecoder returns the decoder shared by all zstd chunk reads. DecodeAll
+// is safe for concurrent use, and sharing it keeps each chunk from paying
+// for a decoder's setup and buffers.
+var zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+	return zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+})
+
+// decodeLZ4 decodes a chunk written by numcodecs' LZ4 codec: a little-endian
+// int32 holding the decoded length, followed by a raw LZ4 block. The output
+// is written into dst's capacity when it is large enough.
+func decodeLZ4(data, dst []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("%w: %d bytes is too short for an lz4 header", ErrChunkCorrupt, len(data))
+	}
+	n := int(int32(binary.LittleEndian.Uint32(data)))
+	if n < 0 {
+		return nil, fmt.Errorf("%w: negative lz4 length %d", ErrChunkCorrupt, n)
+	}
+	out := dst[:0]
+	if cap(out) < n {
+		out = make([]byte, n)
+	}
+	out = out[:n]
+	m, err := lz4.UncompressBlock(data[4:], out)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decompress lz4 data: %v", ErrChunkCorrupt, err)
+	}
+	if m != n {
+		return nil, fmt.Errorf("%w: lz4 block decoded to %d bytes, header says %d", ErrChunkCorrupt, m, n)
+	}
+	return out, nil
+}
+
+// inflate decodes DEFLATE data wrapped in either a gzip or a zlib container.
+// numcodecs' GZip codec writes gzip members while Zlib writes zlib streams,
+// so the container is detected from the magic bytes rather than the id. The
+// output is written into dst's capacity when it is large enough.
+func inflate(data, dst []byte) ([]byte, error) {
+	var (
+		rc  io.ReadCloser
+		err error
+	)
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		rc, err = gzip.NewReader(bytes.NewReader(data))
+	} else {
+		rc, err = zlib.NewReader(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to init inflate reader: %w", err)
+	}
+	defer rc.Close()
+
+	out, err := readInto(rc, dst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inflate data: %w", err)
+	}
+	return out, nil
+}
+
+// readInto reads r to the end, writing into dst's capacity before growing
+// past it.
+func readInto(r io.Reader, dst []byte) ([]byte, error) {
+	out := dst[:cap(dst)]
+	for n := 0; n < len(out); {
+		m, err := r.Read(out[n:])
+		n += m
+		if err == io.EOF {
+			return out[:n], nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// dst is full; append whatever remains, which also checks the trailer.
+	rest, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return append(out, rest...), nil
+}
+
+// readBounded reads r like readInto, failing with ErrChunkCorrupt rather
+// than reading on once the output exceeds limit bytes. A negative limit
+// disables the check.
+func readBounded(id string, r io.Reader, dst []byte, limit int) ([]byte, error) {
+	if limit < 0 {
+		return readInto(r, dst)
+	}
+	out, err := readInto(io.LimitReader(r, int64(limit)+1), dst)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > limit {
+		return nil, fmt.Errorf("%w: %s data decodes to more than the %d bytes a chunk holds", ErrChunkCorrupt, id, limit)
+	}
+	return out, nil
+}
+
+// castagnoli is the CRC32C table; hash/crc32 uses the SSE4.2/ARMv8 CRC
+// instructions for it where available.
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// stripCRC32C validates and removes the 4-byte little-endian CRC32C checksum
+// that the crc32c codec appends to the end of each chunk.
+func stripCRC32C(data []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("%w: %d bytes is too short for a crc32c checksum", ErrChunkCorrupt, len(data))
+	}
+	payload := data[:len(data)-4]
+	want := binary.LittleEndian.Uint32(data[len(data)-4:])
+	if got := crc32.Checksum(payload, castagnoli); got != want {
+		return nil, fmt.Errorf("%w: crc32c mismatch (stored %08x, computed %08x)", ErrChunkCorrupt, want, got)
+	}
+	return payload, nil
+}
+
+// compress encodes chunk bytes with the given compressor, the inverse of
+// decompress. A nil config stores the chunk as is. itemSize is used by
+// blosc's shuffle filter.
+func compress(data []byte, cfg *CompressorConfig, itemSize int) ([]byte, error) {
+	if cfg == nil {
+		return data, nil
+	}
+
+	switch cfg.ID {
+	case "blosc":
+		codec, ok := bloscCodecs[cfg.Cname]
+		if !ok {
+			return nil, fmt.Errorf("unsupported blosc cname: %q", cfg.Cname)
+		}
+		shuffle := blosc.NoShuffle
+		switch cfg.Shuffle {
+		case 1:
+			shuffle = blosc.Shuffle1
+		case 2:
+			shuffle = blosc.BitShuffle
+		case -1:
+			// numcodecs' AUTOSHUFFLE: bit shuffle for single bytes.
+			shuffle = blosc.Shuffle1
+			if itemSize == 1 {
+				shuffle = blosc.BitShuffle
+			}
+		}
+		out, err := blosc.CompressWithOptions(data, blosc.Options{
+			Codec:     codec,
+			Level:     cfg.Clevel,
+			Shuffle:   shuffle,
+			TypeSize:  itemSize,
+			BlockSize: cfg.Blocksize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress blosc data: %w", err)
+		}
+		return out, nil
+	case "gzip", "zlib":
+		level := cfg.Level
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		var (
+			buf bytes.Buffer
+			w   io.WriteCloser
+			err error
+		)
+		if cfg.ID == "gzip" {
+			w, err = gzip.NewWriterLevel(&buf, level)
+		} else {
+			w, err = zlib.NewWriterLevel(&buf, level)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to init %s encoder: %w", cfg.ID, err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to compress %s data: %w", cfg.ID, err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress %s data: %w", cfg.ID, err)
+		}
+		return buf.Bytes(), nil
+	case "zstd":
+		opts := []zstd.EOption{}
+		if cfg.Level != 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(cfg.Level)))
+		}
+		enc, err := zstd.NewWriter(nil, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init zstd encoder: %w", err)
+		}
+		defer enc.Close()
+		return enc.EncodeAll(data, nil), nil
+	case "crc32c":
+		sum := crc32.Checksum(data, castagnoli)
+		return binary.LittleEndian.AppendUint32(bytes.Clone(data), sum), nil
+	default:
+		return nil, fmt.Errorf("unsupported compressor: %s", cfg.ID)
+	}
+}
+
+// bloscCodecs maps numcodecs' blosc cname values to the codecs the blosc
+// package can compress with. blosclz has no Go implementation.
+var bloscCodecs = map[string]blosc.Codec{
+	"lz4":    blosc.LZ4,
+	"lz4hc":  blosc.LZ4HC,
+	"snappy": blosc.Snappy,
+	"zlib":   blosc.ZLIB,
+	"zstd":   blosc.ZSTD,
+}
@@ -0,0 +1,467 @@
+package zarr_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/mrjoshuak/go-blosc"
+	"github.com/pierrec/lz4/v4"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+const crc32cVector4 = `{
+	"zarr_format": 2,
+	"shape": [4],
+	"chunks": [2],
+	"dtype": "<f4",
+	"compressor": {"id": "crc32c"},
+	"fill_value": 0.0,
+	"order": "C"
+}`
+
+func TestReader_CRC32C(t *testing.T) {
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, crc32cVector4, map[string][]float32{
+		"0": {1, 2},
+		"1": {3, 4},
+	})
+
+	// Append the checksum to chunk 0 and a wrong checksum to chunk 1.
+	appendChecksum := func(name string, corrupt bool) {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read chunk %s: %v", name, err)
+		}
+		sum := crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli))
+		if corrupt {
+			sum ^= 1
+		}
+		data = binary.LittleEndian.AppendUint32(data, sum)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("failed to write chunk %s: %v", name, err)
+		}
+	}
+	appendChecksum("0", false)
+	appendChecksum("1", true)
+
+	reader := openReader(t, dir)
+	ctx := context.Background()
+
+	data, err := reader.ReadChunk(ctx, []int{0})
+	if err != nil {
+		t.Fatalf("ReadChunk of a valid chunk failed: %v", err)
+	}
+	if got := decodeFloat32(data); !reflect.DeepEqual(got, []float32{1, 2}) {
+		t.Errorf("expected [1 2], got %v", got)
+	}
+
+	if _, err := reader.ReadChunk(ctx, []int{1}); !errors.Is(err, zarr.ErrChunkCorrupt) {
+		t.Errorf("expected ErrChunkCorrupt for a corrupted chunk, got %v", err)
+	}
+	if _, err := reader.ReadFull(ctx); !errors.Is(err, zarr.ErrChunkCorrupt) {
+		t.Errorf("expected ReadFull to surface ErrChunkCorrupt, got %v", err)
+	}
+}
+
+func TestReader_Compressors(t *testing.T) {
+	gzipped := func(data []byte) []byte {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		w.Write(data)
+		w.Close()
+		return buf.Bytes()
+	}
+	zlibbed := func(data []byte) []byte {
+		var buf bytes.Buffer
+		w := zlib.NewWriter(&buf)
+		w.Write(data)
+		w.Close()
+		return buf.Bytes()
+	}
+	zstded := func(data []byte) []byte {
+		enc, err := zstd.NewWriter(nil)
+		if err != nil {
+			t.Fatalf("failed to init zstd encoder: %v", err)
+		}
+		defer enc.Close()
+		return enc.EncodeAll(data, nil)
+	}
+
+	tests := []struct {
+		id     string
+		encode func([]byte) []byte
+	}{
+		{"gzip", gzipped},
+		{"zlib", zlibbed},
+		{"zstd", zstded},
+		{"lz4", func(data []byte) []byte { return lz4Chunk(t, data) }},
+		{"brotli", func(data []byte) []byte { return brotliChunk(t, data) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			dir := t.TempDir()
+			writeArray(t, dir, `{
+				"zarr_format": 2,
+				"shape": [4],
+				"chunks": [2],
+				"dtype": "<f4",
+				"compressor": {"id": "`+tt.id+`"},
+				"fill_value": 0.0,
+				"order": "C"
+			}`, map[string][]byte{
+				"0": tt.encode(encodeLE(t, []float32{1, 2})),
+				"1": tt.encode(encodeLE(t, []float32{3, 4})),
+			})
+			reader := openReader(t, dir)
+
+			data, err := reader.ReadFull(context.Background())
+			if err != nil {
+				t.Fatalf("ReadFull failed: %v", err)
+			}
+			if got := decodeFloat32(data); !reflect.DeepEqual(got, []float32{1, 2, 3, 4}) {
+				t.Errorf("expected [1 2 3 4], got %v", got)
+			}
+		})
+	}
+}
+
+// lz4Chunk encodes data like numcodecs' LZ4 codec: a little-endian int32
+// length followed by an LZ4 block.
+func lz4Chunk(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	block := make([]byte, lz4.CompressBlockBound(len(data)))
+	n, err := lz4.CompressBlock(data, block, nil)
+	if err != nil {
+		t.Fatalf("failed to compress lz4 block: %v", err)
+	}
+	if n == 0 {
+		// Too short to compress: a block holding only literals.
+		block = append([]byte{byte(len(data)) << 4}, data...)
+		n = len(block)
+	}
+	return append(binary.LittleEndian.AppendUint32(nil, uint32(len(data))), block[:n]...)
+}
+
+func TestReader_BZ2(t *testing.T) {
+	// Go has no bzip2 encoder; this chunk was written by Python's
+	// bz2.compress(struct.pack("<4f", 1.5, -2, 3, 4), 1).
+	chunk, err := hex.DecodeString("425a6831314159265359a40876970000024c4440000000c0004000400020002129e8419a075aa126a1e2ee48a70a1214810ed2e0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [6],
+			"chunks": [4],
+			"dtype": "<f4",
+			"compressor": {"id": "bz2", "level": 1},
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+		"0": chunk,
+		"1": []byte("not bzip2"),
+	})
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	data, err := reader.ReadRegion(ctx, []int{0}, []int{4})
+	if err != nil {
+		t.Fatalf("ReadRegion failed: %v", err)
+	}
+	if want := []float32{1.5, -2, 3, 4}; !reflect.DeepEqual(decodeFloat32(data), want) {
+		t.Errorf("expected %v, got %v", want, decodeFloat32(data))
+	}
+	if _, err := reader.ReadChunk(ctx, []int{1}); err == nil || !strings.Contains(err.Error(), "bz2") {
+		t.Errorf("expected a bz2 decoding error, got %v", err)
+	}
+
+	// The same stream holds twice what a chunk of two elements can.
+	small := openFake(t, newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [2],
+			"chunks": [2],
+			"dtype": "<f4",
+			"compressor": {"id": "bz2", "level": 1},
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+		"0": chunk,
+	}))
+	if _, err := small.ReadChunk(ctx, []int{0}); !errors.Is(err, zarr.ErrChunkCorrupt) {
+		t.Errorf("expected ErrChunkCorrupt for an oversized bz2 stream, got %v", err)
+	}
+}
+
+// brotliChunk encodes data like numcodecs' Brotli codec: a bare brotli
+// stream.
+func brotliChunk(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := brotli.NewWriterLevel(&buf, brotli.BestCompression)
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("failed to compress brotli data: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to compress brotli data: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReader_Brotli(t *testing.T) {
+	values := make([]float32, 1024)
+	for i := range values {
+		values[i] = float32(i % 16)
+	}
+	raw := encodeLE(t, values)
+	chunk := brotliChunk(t, raw)
+	if len(chunk) >= len(raw) {
+		t.Fatalf("expected the test chunk to compress, got %d bytes from %d", len(chunk), len(raw))
+	}
+
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [3072],
+			"chunks": [1024],
+			"dtype": "<f4",
+			"compressor": {"id": "brotli", "level": 11},
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+		"0": chunk,
+		"1": []byte("not brotli"),
+		// A stream decoding to far more than a chunk, as a decompression
+		// bomb would.
+		"2": brotliChunk(t, make([]byte, 1<<20)),
+	})
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	data, err := reader.ReadChunk(ctx, []int{0})
+	if err != nil {
+		t.Fatalf("ReadChunk failed: %v", err)
+	}
+	if !bytes.Equal(data, raw) {
+		t.Error("brotli chunk did not round-trip")
+	}
+	if _, err := reader.ReadChunk(ctx, []int{1}); err == nil || !strings.Contains(err.Error(), "brotli") {
+		t.Errorf("expected a brotli decoding error, got %v", err)
+	}
+	if _, err := reader.ReadChunk(ctx, []int{2}); !errors.Is(err, zarr.ErrChunkCorrupt) {
+		t.Errorf("expected ErrChunkCorrupt for an oversized brotli stream, got %v", err)
+	}
+}
+
+func TestReader_LZ4(t *testing.T) {
+	values := make([]float32, 1024)
+	for i := range values {
+		values[i] = float32(i % 16)
+	}
+	raw := encodeLE(t, values)
+	chunk := lz4Chunk(t, raw)
+	if len(chunk) >= len(raw) {
+		t.Fatalf("expected the test chunk to compress, got %d bytes from %d", len(chunk), len(raw))
+	}
+	forged := binary.LittleEndian.AppendUint32(nil, 1<<30)
+	forged = append(forged, chunk[4:]...)
+
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [2048],
+			"chunks": [1024],
+			"dtype": "<f4",
+			"compressor": {"id": "lz4", "acceleration": 1},
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+		"0": chunk,
+		"1": forged,
+	})
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	data, err := reader.ReadChunk(ctx, []int{0})
+	if err != nil {
+		t.Fatalf("ReadChunk failed: %v", err)
+	}
+	if !bytes.Equal(data, raw) {
+		t.Error("lz4 chunk decoded to different bytes")
+	}
+	if _, err := reader.ReadChunk(ctx, []int{1}); !errors.Is(err, zarr.ErrChunkCorrupt) {
+		t.Errorf("expected ErrChunkCorrupt for a forged length header, got %v", err)
+	}
+}
+
+func TestNewReaderWithDecompressors(t *testing.T) {
+	// A toy codec that stores every byte XORed with 0xff.
+	xor := func(data []byte) []byte {
+		out := make([]byte, len(data))
+		for i, b := range data {
+			out[i] = b ^ 0xff
+		}
+		return out
+	}
+
+	dir := t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [4],
+		"chunks": [2],
+		"dtype": "<f4",
+		"compressor": {"id": "xor"},
+		"fill_value": 0.0,
+		"order": "C"
+	}`, map[string][]byte{
+		"0": xor(encodeLE(t, []float32{1, 2})),
+		"1": xor(encodeLE(t, []float32{3, 4})),
+	})
+	ctx := context.Background()
+	url := "file:///" + filepath.ToSlash(dir)
+
+	plain := openReader(t, dir)
+	if _, err := plain.ReadFull(ctx); err == nil {
+		t.Error("expected the default reader to reject the unknown codec")
+	}
+
+	reader, err := zarr.NewReaderWithDecompressors(ctx, url, map[string]func([]byte) ([]byte, error){
+		"xor": func(data []byte) ([]byte, error) { return xor(data), nil },
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithDecompressors failed: %v", err)
+	}
+	defer reader.Close()
+
+	data, err := reader.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull with injected codec failed: %v", err)
+	}
+	if got := decodeFloat32(data); !reflect.DeepEqual(got, []float32{1, 2, 3, 4}) {
+		t.Errorf("expected [1 2 3 4], got %v", got)
+	}
+}
+
+func TestReader_MalformedBlosc(t *testing.T) {
+	valid, err := blosc.Compress(encodeLE(t, []float32{1, 2, 3, 4}), blosc.LZ4, 5, blosc.Shuffle1, 4)
+	if err != nil {
+		t.Fatalf("failed to compress test chunk: %v", err)
+	}
+	// A header claiming 2 GiB of output for a 16-byte chunk.
+	oversized := []byte{2, 1, 0x01, 4, 0xff, 0xff, 0xff, 0x7f, 0, 0, 1, 0, 32, 0, 0, 0}
+	oversized = append(oversized, make([]byte, 16)...)
+
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [12],
+			"chunks": [4],
+			"dtype": "<f4",
+			"compressor": {"id": "blosc", "cname": "lz4", "clevel": 5, "shuffle": 1},
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+		"0": valid,
+		"1": valid[:len(valid)-5],
+		"2": oversized,
+	})
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	if _, err := reader.ReadChunk(ctx, []int{0}); err != nil {
+		t.Fatalf("ReadChunk on a valid chunk failed: %v", err)
+	}
+	if _, err := reader.ReadChunk(ctx, []int{1}); err == nil || !strings.Contains(err.Error(), "chunk 1") {
+		t.Errorf("expected an error naming chunk 1 for a truncated chunk, got %v", err)
+	}
+	_, err = reader.ReadChunk(ctx, []int{2})
+	if !errors.Is(err, zarr.ErrChunkCorrupt) || !strings.Contains(err.Error(), "chunk 2") {
+		t.Errorf("expected ErrChunkCorrupt naming chunk 2 for an oversized header, got %v", err)
+	}
+}
+
+func TestReader_DecoderPanicRecovered(t *testing.T) {
+	dir := t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [2],
+		"chunks": [2],
+		"dtype": "<f4",
+		"compressor": {"id": "boom"},
+		"fill_value": 0.0,
+		"order": "C"
+	}`, map[string][]byte{"0": {1, 2, 3}})
+	ctx := context.Background()
+
+	reader, err := zarr.NewReaderWithDecompressors(ctx, "file:///"+filepath.ToSlash(dir), map[string]func([]byte) ([]byte, error){
+		"boom": func(data []byte) ([]byte, error) { return nil, fmt.Errorf("%d", data[10]) },
+	})
+	if err != nil {
+		t.Fatalf("NewReaderWithDecompressors failed: %v", err)
+	}
+	defer reader.Close()
+
+	_, err = reader.ReadFull(ctx)
+	if !errors.Is(err, zarr.ErrChunkCorrupt) || !strings.Contains(err.Error(), "panicked") {
+		t.Errorf("expected a recovered decoder panic, got %v", err)
+	}
+}
+
+func BenchmarkReader_ReadFullZstd(b *testing.B) {
+	const size, chunk = 128, 8
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		b.Fatalf("failed to init zstd encoder: %v", err)
+	}
+	defer enc.Close()
+
+	values := make([]float32, size*size)
+	for i := range values {
+		values[i] = float32(i)
+	}
+	fb := newFakeBucket(map[string][]byte{".zarray": []byte(fmt.Sprintf(`{
+		"zarr_format": 2,
+		"shape": [%d, %d],
+		"chunks": [%d, %d],
+		"dtype": "<f4",
+		"compressor": {"id": "zstd", "level": 1},
+		"fill_value": 0.0,
+		"order": "C"
+	}`, size, size, chunk, chunk))})
+	for key, data := range chunkFloat32([]int{size, size}, []int{chunk, chunk}, values) {
+		raw, err := binary.Append(nil, binary.LittleEndian, data)
+		if err != nil {
+			b.Fatalf("failed to encode chunk %s: %v", key, err)
+		}
+		fb.put(key, enc.EncodeAll(raw, nil))
+	}
+	reader := openFake(b, fb)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := reader.ReadFull(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
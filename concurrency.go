@@ -0,0 +1,100 @@
+package zarr
+
+import (
+	"context"
+	"slices"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ReaderOptions holds reader-wide settings applied with WithOptions.
+type ReaderOptions struct {
+	// Concurrency bounds how many chunks ReadFull and ReadRegion fetch and
+	// decode at once. Values below 2 read chunks one at a time, in the
+	// read's chunk order. Decompressors passed to NewReaderWithDecompressors
+	// must be safe for concurrent use when it is above 1.
+	Concurrency int
+}
+
+// WithOptions returns a view of the array that reads with the given
+// options.
+func (r *Reader) WithOptions(opts ReaderOptions) *Reader {
+	v := r.view()
+	v.concurrency = opts.Concurrency
+	return v
+}
+
+// visitChunks calls fn for every chunk between first and last, dispatching
+// them in the given order to up to r.concurrency workers. fn must only write
+// to memory no other chunk touches. The first error cancels the context
+// passed to the remaining calls and is returned.
+func (r *Reader) visitChunks(ctx context.Context, first, last []int, order ChunkOrder, fn func(ctx context.Context, coords []int) error) error {
+	if r.concurrency < 2 {
+		return forEachChunk(first, last, order, func(coords []int) error {
+			// Stop between chunks once the read is cancelled, rather than
+			// leaving it to each fetch to notice.
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return fn(ctx, coords)
+		})
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(r.concurrency)
+	dispatchErr := forEachChunk(first, last, order, func(coords []int) error {
+		if err := gctx.Err(); err != nil {
+			return err
+		}
+		coords = slices.Clone(coords)
+		g.Go(func() error {
+			return fn(gctx, coords)
+		})
+		return nil
+	})
+	// A failed worker cancels gctx, which stops dispatching; report the
+	// worker's error rather than the cancellation.
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	return dispatchErr
+}
+
+// RegionFuture is the pending result of a PreloadRegion call.
+type RegionFuture struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// PreloadRegion starts reading a region like ReadRegion in the background
+// and returns at once. The read uses the reader's concurrency and stops
+// early if ctx is cancelled. Chunk downloads already in flight for another
+// read of the reader or one of its views, such as an overlapping preload,
+// are joined rather than repeated. On a reader with a cache, set with
+// WithCache, the chunks the preload fetches are also added to the cache, so
+// later reads of them skip the fetch; without one, the region is only
+// available through the future.
+func (r *Reader) PreloadRegion(ctx context.Context, start, shape []int, opts ...ReadOption) *RegionFuture {
+	f := &RegionFuture{done: make(chan struct{})}
+	start, shape = slices.Clone(start), slices.Clone(shape)
+	go func() {
+		defer close(f.done)
+		f.data, f.err = r.ReadRegion(ctx, start, shape, opts...)
+	}()
+	return f
+}
+
+// Wait blocks until the region has been read and returns it, or the error
+// that stopped the read. It may be called any number of times, from any
+// goroutine, and always returns the same result.
+func (f *RegionFuture) Wait() ([]byte, error) {
+	<-f.done
+	return f.data, f.err
+}
+
+// Done returns a channel that is closed once the result is ready, for use in
+// select statements.
+func (f *RegionFuture) Done() <-chan struct{} {
+	return f.done
+}
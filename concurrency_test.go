@@ -0,0 +1,300 @@
+package zarr_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"gocloud.dev/blob"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_WithOptionsConcurrency(t *testing.T) {
+	fb, _ := gzipArray(t, 64, 8)
+	sequential := openFake(t, fb)
+	parallel := sequential.WithOptions(zarr.ReaderOptions{Concurrency: 8})
+	ctx := context.Background()
+
+	want, err := sequential.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("sequential ReadFull failed: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		got, err := parallel.ReadFull(ctx, zarr.WithChunkOrder(zarr.ChunkOrderMorton))
+		if err != nil {
+			t.Fatalf("concurrent ReadFull failed: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatal("concurrent ReadFull differs from the sequential result")
+		}
+	}
+
+	wantRegion, err := sequential.ReadRegion(ctx, []int{3, 5}, []int{50, 41})
+	if err != nil {
+		t.Fatalf("sequential ReadRegion failed: %v", err)
+	}
+	gotRegion, err := parallel.ReadRegion(ctx, []int{3, 5}, []int{50, 41})
+	if err != nil {
+		t.Fatalf("concurrent ReadRegion failed: %v", err)
+	}
+	if !bytes.Equal(gotRegion, wantRegion) {
+		t.Error("concurrent ReadRegion differs from the sequential result")
+	}
+}
+
+func TestReader_ReadRegionConcurrentViews(t *testing.T) {
+	// The region covers 2x3 chunks of an uncompressed array, so the
+	// workers copy range reads as well as whole chunks into disjoint parts
+	// of the output, written back to front along flipped axes.
+	dir := t.TempDir()
+	values := make([]float32, 8*12)
+	for i := range values {
+		values[i] = float32(i)
+	}
+	writeFloat32Array(t, dir, `{
+		"zarr_format": 2,
+		"shape": [8, 12],
+		"chunks": [4, 4],
+		"dtype": "<f4",
+		"compressor": null,
+		"fill_value": 0.0,
+		"order": "C"
+	}`, chunkFloat32([]int{8, 12}, []int{4, 4}, values))
+	base := openReader(t, dir)
+	ctx := context.Background()
+
+	for name, view := range map[string]*zarr.Reader{
+		"plain":      base,
+		"transposed": base.Transpose([]int{1, 0}),
+		"flipped":    base.Flip([]int{0, 1}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			shape := view.Shape()
+			start, stop := []int{1, 1}, []int{shape[0] - 1, shape[1] - 1}
+			count := []int{stop[0] - start[0], stop[1] - start[1]}
+			parallel := view.WithOptions(zarr.ReaderOptions{Concurrency: 6})
+
+			want, err := view.ReadRegion(ctx, start, count)
+			if err != nil {
+				t.Fatalf("sequential ReadRegion failed: %v", err)
+			}
+			got, err := parallel.ReadRegion(ctx, start, count)
+			if err != nil {
+				t.Fatalf("concurrent ReadRegion failed: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Error("concurrent ReadRegion differs from the sequential result")
+			}
+
+			want, err = view.ReadRegionStrided(ctx, start, stop, []int{2, 3})
+			if err != nil {
+				t.Fatalf("sequential ReadRegionStrided failed: %v", err)
+			}
+			got, err = parallel.ReadRegionStrided(ctx, start, stop, []int{2, 3})
+			if err != nil {
+				t.Fatalf("concurrent ReadRegionStrided failed: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Error("concurrent ReadRegionStrided differs from the sequential result")
+			}
+		})
+	}
+}
+
+func TestReader_WithOptionsConcurrencyErrors(t *testing.T) {
+	fb, _ := gzipArray(t, 64, 8)
+	delete(fb.objects, "3.4")
+	reader := openFake(t, fb).WithOptions(zarr.ReaderOptions{Concurrency: 4})
+
+	_, err := reader.ReadFull(context.Background(), zarr.WithNotFoundPolicy(zarr.NotFoundError))
+	if !errors.Is(err, zarr.ErrChunkNotFound) {
+		t.Errorf("expected ErrChunkNotFound from a worker, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := reader.ReadFull(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a canceled read to fail with context.Canceled, got %v", err)
+	}
+}
+
+// writeFakeArray creates a 64x64 float32 array in 8x8 chunks in fb with the
+// given compressor.
+func writeFakeArray(t *testing.T, fb *fakeBucket, cfg *zarr.CompressorConfig) {
+	t.Helper()
+
+	shape, chunks := []int{64, 64}, []int{8, 8}
+	values := make([]float32, 64*64)
+	for i := range values {
+		values[i] = float32(i)
+	}
+	store := zarr.NewSharedBucket(blob.NewBucket(fb))
+	defer store.Close()
+	w, err := store.CreateArray(context.Background(), "", &zarr.Metadata{
+		Shape: shape, Chunks: chunks, DType: "<f4", Compressor: cfg,
+	})
+	if err != nil {
+		t.Fatalf("CreateArray failed: %v", err)
+	}
+	defer w.Close()
+	grid := zarr.GridShape(shape, chunks)
+	for i := 0; i < grid[0]; i++ {
+		for j := 0; j < grid[1]; j++ {
+			chunk := make([]float32, 64)
+			for k := range chunk {
+				chunk[k] = values[(i*8+k/8)*64+j*8+k%8]
+			}
+			if err := w.WriteChunk(context.Background(), []int{i, j}, encodeLE(t, chunk)); err != nil {
+				t.Fatalf("WriteChunk failed: %v", err)
+			}
+		}
+	}
+}
+
+func TestReader_ConcurrencyCancelReleasesResources(t *testing.T) {
+	for _, id := range []string{"gzip", "zstd"} {
+		t.Run(id, func(t *testing.T) {
+			fb := newFakeBucket(nil)
+			writeFakeArray(t, fb, &zarr.CompressorConfig{ID: id})
+			reader := openFake(t, fb).WithOptions(zarr.ReaderOptions{Concurrency: 8})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var reads atomic.Int32
+			fb.beforeRead = func(ctx context.Context, key string) error {
+				if reads.Add(1) == 10 {
+					cancel()
+				}
+				time.Sleep(time.Millisecond)
+				return nil
+			}
+
+			baseline := runtime.NumGoroutine()
+			if _, err := reader.ReadFull(ctx); !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected context.Canceled, got %v", err)
+			}
+			if n := reads.Load(); n >= 64 {
+				t.Errorf("expected the cancelled read to stop fetching, but all %d chunks were read", n)
+			}
+			if n := fb.openReaders(); n != 0 {
+				t.Errorf("%d chunk readers left open", n)
+			}
+
+			// Goroutines from finished workers may take a moment to exit.
+			deadline := time.Now().Add(2 * time.Second)
+			for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			if n := runtime.NumGoroutine(); n > baseline {
+				t.Errorf("goroutines leaked: %d before the read, %d after", baseline, n)
+			}
+		})
+	}
+}
+
+func TestReader_PreloadRegion(t *testing.T) {
+	fb, _ := gzipArray(t, 64, 8)
+	reader := openFake(t, fb).WithOptions(zarr.ReaderOptions{Concurrency: 4})
+	ctx := context.Background()
+
+	future := reader.PreloadRegion(ctx, []int{3, 5}, []int{50, 41})
+	want, err := reader.ReadRegion(ctx, []int{3, 5}, []int{50, 41})
+	if err != nil {
+		t.Fatalf("ReadRegion failed: %v", err)
+	}
+	<-future.Done()
+	for i := 0; i < 2; i++ {
+		got, err := future.Wait()
+		if err != nil {
+			t.Fatalf("Wait failed: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatal("preloaded region differs from the synchronous read")
+		}
+	}
+
+	if _, err := reader.PreloadRegion(ctx, []int{60, 0}, []int{8, 8}).Wait(); err == nil {
+		t.Error("expected an out-of-bounds preload to fail")
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := reader.PreloadRegion(cancelled, []int{0, 0}, []int{64, 64}).Wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled preload to report context.Canceled, got %v", err)
+	}
+}
+
+func TestReader_PreloadRegionSharesFetches(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		// 4x4 chunks of 8x8. Every read stalls, so both preloads have asked
+		// for all their chunks before any download completes.
+		fb, _ := gzipArray(t, 32, 8)
+		fb.beforeRead = func(ctx context.Context, key string) error {
+			time.Sleep(time.Second)
+			return nil
+		}
+		reader := openFake(t, fb).WithOptions(zarr.ReaderOptions{Concurrency: 16})
+		ctx := context.Background()
+
+		// Rows 0-2 and 1-3 of chunks overlap in rows 1-2; a flipped view
+		// shares the reader's in-flight downloads too.
+		a := reader.PreloadRegion(ctx, []int{0, 0}, []int{24, 32})
+		b := reader.Flip([]int{1}).PreloadRegion(ctx, []int{8, 0}, []int{24, 32})
+		if _, err := a.Wait(); err != nil {
+			t.Fatalf("first preload failed: %v", err)
+		}
+		if _, err := b.Wait(); err != nil {
+			t.Fatalf("second preload failed: %v", err)
+		}
+
+		for _, key := range []string{"0.0", "1.1", "2.3", "3.2"} {
+			if n := len(fb.readsOf(key)); n != 1 {
+				t.Errorf("expected chunk %s to be downloaded once, got %d", key, n)
+			}
+		}
+		if n := fb.readCount(); n != 16 {
+			t.Errorf("expected 16 chunk downloads for 16 distinct chunks, got %d", n)
+		}
+
+		// The shared results are still correct and independent.
+		want, err := reader.Flip([]int{1}).ReadRegion(ctx, []int{8, 0}, []int{24, 32})
+		if err != nil {
+			t.Fatalf("ReadRegion failed: %v", err)
+		}
+		if got, _ := b.Wait(); !bytes.Equal(got, want) {
+			t.Error("preloaded region differs from the synchronous read")
+		}
+	})
+}
+
+func TestReader_ReadFullCancelledMidRead(t *testing.T) {
+	fb, _ := gzipArray(t, 16, 4)
+	reader := openFake(t, fb)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Cancel as soon as the first chunk is fetched.
+	fb.beforeRead = func(context.Context, string) error {
+		cancel()
+		return nil
+	}
+	if _, err := reader.ReadFull(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if n := fb.readCount(); n != 1 {
+		t.Errorf("expected the read to stop after the first chunk, got %d fetches", n)
+	}
+
+	fb.resetReads()
+	if _, err := reader.Histogram(ctx, 4, 0, 256); !errors.Is(err, context.Canceled) {
+		t.Errorf("Histogram: expected context.Canceled, got %v", err)
+	}
+	if n := fb.readCount(); n != 0 {
+		t.Errorf("expected no fetches with a cancelled context, got %d", n)
+	}
+}
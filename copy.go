@@ -0,0 +1,137 @@
+package zarr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
+)
+
+// CopyOptions configures Reader.CopyTo.
+type CopyOptions struct {
+	// Progress, if set, is called after each chunk of the grid has been
+	// handled with the number of chunks done so far and the grid size.
+	// Calls are serialized even when chunks are copied concurrently, see
+	// ReaderOptions.Concurrency.
+	Progress func(done, total int)
+
+	// Resume skips chunks that already exist in the destination, so that an
+	// interrupted copy can be continued without redoing finished work.
+	Resume bool
+}
+
+// CopyTo copies the array's metadata and stored chunks, as stored and without
+// re-encoding, to the bucket at the given gocloud URL. The context is checked
+// between chunks so long copies can be cancelled and later resumed with
+// CopyOptions.Resume.
+func (r *Reader) CopyTo(ctx context.Context, dstPath string, opts CopyOptions) error {
+	dst, err := blob.OpenBucket(ctx, dstPath)
+	if err != nil {
+		return fmt.Errorf("failed to create destination bucket: %w", err)
+	}
+	defer dst.Close()
+
+	for _, name := range []string{".zarray", ".zattrs"} {
+		if err := r.copyObject(ctx, dst, name); err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+			return err
+		}
+	}
+
+	grid := GridShape(r.meta.Shape, r.meta.Chunks)
+	total := 1
+	for _, n := range grid {
+		total *= n
+	}
+
+	last := make([]int, len(grid))
+	for i, n := range grid {
+		last[i] = n - 1
+	}
+	var (
+		mu   sync.Mutex
+		done int
+	)
+	return r.visitChunks(ctx, make([]int, len(grid)), last, ChunkOrderC, func(ctx context.Context, coords []int) error {
+		if err := r.copyChunk(ctx, dst, coords, opts.Resume); err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		done++
+		if opts.Progress != nil {
+			opts.Progress(done, total)
+		}
+		return nil
+	})
+}
+
+// copyChunk copies a single stored chunk, skipping chunks that are absent in
+// the source and, when resuming, chunks already present in the destination.
+func (r *Reader) copyChunk(ctx context.Context, dst *blob.Bucket, coords []int, resume bool) error {
+	key := r.chunkKey(coords)
+	if resume {
+		exists, err := dst.Exists(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to check destination chunk %s: %w", key, err)
+		}
+		if exists {
+			return nil
+		}
+	}
+	deduped, err := r.deduplicated(ctx)
+	if err != nil {
+		return err
+	}
+	if deduped {
+		// Store the chunk itself rather than a reference to a blob the
+		// destination does not have.
+		return r.copyResolved(ctx, dst, key)
+	}
+	if err := r.copyObject(ctx, dst, key); err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+		return err
+	}
+	return nil
+}
+
+// copyResolved copies a chunk of a deduplicated array to the same key in
+// dst, following a reference entry to the chunk's stored bytes.
+func (r *Reader) copyResolved(ctx context.Context, dst *blob.Bucket, key string) error {
+	data, err := r.fetchObject(ctx, key)
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil
+		}
+		return err
+	}
+	if err := dst.WriteAll(ctx, key, data, nil); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", key, err)
+	}
+	return nil
+}
+
+// copyObject streams one object of the array to the same key, relative to
+// the array, in dst. Errors opening the source are returned unwrapped so
+// that callers can test for NotFound.
+func (r *Reader) copyObject(ctx context.Context, dst *blob.Bucket, name string) error {
+	src, err := r.store.bucket.NewReader(ctx, r.key(name), nil)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	w, err := dst.NewWriter(ctx, name, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", name, err)
+	}
+	if _, err := io.Copy(w, src); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to copy %s: %w", name, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", name, err)
+	}
+	return nil
+}
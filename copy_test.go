@@ -0,0 +1,113 @@
+package zarr_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_CopyToCancelAndResume(t *testing.T) {
+	src := openSequential(t, []int{4, 6}, []int{2, 2})
+	dir := t.TempDir()
+	url := "file:///" + filepath.ToSlash(dir)
+
+	// Cancel after the second of six chunks.
+	ctx, cancel := context.WithCancel(context.Background())
+	err := src.CopyTo(ctx, url, zarr.CopyOptions{
+		Progress: func(done, total int) {
+			if total != 6 {
+				t.Errorf("expected a total of 6 chunks, got %d", total)
+			}
+			if done == 2 {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	for _, key := range []string{"0.0", "0.1"} {
+		if _, err := os.Stat(filepath.Join(dir, key)); err != nil {
+			t.Errorf("expected chunk %s to be copied before cancelling: %v", key, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "0.2")); !os.IsNotExist(err) {
+		t.Errorf("expected chunk 0.2 not to be copied after cancelling, got %v", err)
+	}
+
+	// Mark a finished chunk so we can tell whether resuming rewrote it.
+	marker := []byte("already copied")
+	if err := os.WriteFile(filepath.Join(dir, "0.0"), marker, 0644); err != nil {
+		t.Fatalf("failed to mark chunk: %v", err)
+	}
+
+	var last int
+	err = src.CopyTo(context.Background(), url, zarr.CopyOptions{
+		Resume:   true,
+		Progress: func(done, total int) { last = done },
+	})
+	if err != nil {
+		t.Fatalf("resumed CopyTo failed: %v", err)
+	}
+	if last != 6 {
+		t.Errorf("expected progress to reach 6, got %d", last)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "0.0")); !bytes.Equal(data, marker) {
+		t.Error("resume rewrote a chunk that already existed in the destination")
+	}
+
+	// Restore the marked chunk and compare the complete copy.
+	if err := src.CopyTo(context.Background(), url, zarr.CopyOptions{}); err != nil {
+		t.Fatalf("CopyTo failed: %v", err)
+	}
+	dst := openReader(t, dir)
+	want, err := src.ReadFull(context.Background())
+	if err != nil {
+		t.Fatalf("ReadFull on source failed: %v", err)
+	}
+	got, err := dst.ReadFull(context.Background())
+	if err != nil {
+		t.Fatalf("ReadFull on copy failed: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("copied array differs from the source")
+	}
+}
+
+func TestReader_CopyToConcurrent(t *testing.T) {
+	src := openSequential(t, []int{8, 8}, []int{2, 2}).WithOptions(zarr.ReaderOptions{Concurrency: 4})
+	dir := t.TempDir()
+
+	var calls []int
+	err := src.CopyTo(context.Background(), "file:///"+filepath.ToSlash(dir), zarr.CopyOptions{
+		Progress: func(done, total int) { calls = append(calls, done) },
+	})
+	if err != nil {
+		t.Fatalf("CopyTo failed: %v", err)
+	}
+	if len(calls) != 16 {
+		t.Fatalf("expected 16 progress calls, got %d", len(calls))
+	}
+	for i, done := range calls {
+		if done != i+1 {
+			t.Fatalf("expected progress to count up one chunk at a time, got %v", calls)
+		}
+	}
+
+	want, err := src.ReadFull(context.Background())
+	if err != nil {
+		t.Fatalf("ReadFull on source failed: %v", err)
+	}
+	got, err := openReader(t, dir).ReadFull(context.Background())
+	if err != nil {
+		t.Fatalf("ReadFull on copy failed: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("copied array differs from the source")
+	}
+}
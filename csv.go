@@ -0,0 +1,116 @@
+package zarr
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// FormatOptions controls how text outputs such as WriteRegionCSV and
+// StreamRecords render floating-point values. The zero value selects the
+// shortest representation that reads back to the same value.
+type FormatOptions struct {
+	// Precision, if set, is the number of digits after the decimal point, or
+	// after the leading digit in scientific notation; new(0) rounds to whole
+	// numbers. Nil means the shortest exact representation.
+	Precision *int
+	// Scientific selects d.dddde±dd notation.
+	Scientific bool
+	// NaNString replaces "NaN" in CSV output. JSON lines always render NaN
+	// as null, since JSON has no NaN.
+	NaNString string
+}
+
+// WithFormatOptions returns a view that formats floating-point values in
+// text outputs according to opts.
+func (r *Reader) WithFormatOptions(opts FormatOptions) *Reader {
+	v := r.view()
+	if opts.Precision != nil {
+		// Keep later changes to the caller's int from reaching the view.
+		opts.Precision = new(*opts.Precision)
+	}
+	v.format = opts
+	return v
+}
+
+// formatFloat formats a float with the reader's FormatOptions.
+func (r *Reader) formatFloat(f float64, bitSize int) string {
+	o := r.format
+	if o.NaNString != "" && math.IsNaN(f) {
+		return o.NaNString
+	}
+	format, precision := byte('g'), -1
+	if o.Scientific {
+		format = 'e'
+	} else if o.Precision != nil {
+		format = 'f'
+	}
+	if o.Precision != nil {
+		precision = *o.Precision
+	}
+	return strconv.FormatFloat(f, format, precision, bitSize)
+}
+
+// formatElement formats element i of a numeric slice as text.
+func (r *Reader) formatElement(slice reflect.Value, i int) string {
+	elem := slice.Index(i)
+	switch elem.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(elem.Int(), 10)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(elem.Uint(), 10)
+	case reflect.Float32:
+		return r.formatFloat(elem.Float(), 32)
+	default:
+		return r.formatFloat(elem.Float(), 64)
+	}
+}
+
+// isNumericKind reports whether values of the kind can be written as a single
+// numeric text field.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// WriteRegionCSV writes a region of a rank-2 numeric array to w as CSV, one
+// line per row of the region.
+func (r *Reader) WriteRegionCSV(ctx context.Context, start, shape []int, w io.Writer) error {
+	if len(r.meta.Shape) != 2 {
+		return fmt.Errorf("CSV export requires a 2D array, got rank %d", len(r.meta.Shape))
+	}
+
+	values, regionShape, err := r.ReadRegionReflect(ctx, start, shape)
+	if err != nil {
+		return err
+	}
+	if !isNumericKind(values.Type().Elem().Kind()) {
+		return fmt.Errorf("CSV export requires a numeric dtype, got %s", r.meta.DType)
+	}
+
+	cw := csv.NewWriter(w)
+	rows, cols := regionShape[0], regionShape[1]
+	record := make([]string, cols)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			record[col] = r.formatElement(values, row*cols+col)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row %d: %w", row, err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package zarr_test
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_WriteRegionCSV(t *testing.T) {
+	reader := openSequential4x4(t)
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	if err := reader.WriteRegionCSV(ctx, []int{1, 1}, []int{2, 3}, &buf); err != nil {
+		t.Fatalf("WriteRegionCSV failed: %v", err)
+	}
+	if want := "5,6,7\n9,10,11\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	if err := reader.WithFormatOptions(zarr.FormatOptions{Precision: new(2)}).WriteRegionCSV(ctx, []int{0, 2}, []int{1, 2}, &buf); err != nil {
+		t.Fatalf("WriteRegionCSV with float format failed: %v", err)
+	}
+	if want := "2.00,3.00\n"; buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestReader_WriteRegionCSVRejectsNon2D(t *testing.T) {
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, vector4, map[string][]float32{"0": {1, 2}})
+	reader := openReader(t, dir)
+
+	var buf bytes.Buffer
+	if err := reader.WriteRegionCSV(context.Background(), []int{0}, []int{2}, &buf); err == nil {
+		t.Error("expected an error for a 1D array")
+	}
+}
+
+func TestReader_WithFormatOptions(t *testing.T) {
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, `{
+		"zarr_format": 2,
+		"shape": [2, 3],
+		"chunks": [2, 3],
+		"dtype": "<f4",
+		"compressor": null,
+		"fill_value": 0.0,
+		"order": "C"
+	}`, map[string][]float32{
+		"0.0": {1.5, float32(math.NaN()), 1234.5678, -0.25, 0, 1e-7},
+	})
+	reader := openReader(t, dir)
+	ctx := context.Background()
+
+	tests := []struct {
+		opts zarr.FormatOptions
+		want string
+	}{
+		{zarr.FormatOptions{}, "1.5,NaN,1234.5677\n-0.25,0,1e-07\n"},
+		{zarr.FormatOptions{Precision: new(2), NaNString: "NA"}, "1.50,NA,1234.57\n-0.25,0.00,0.00\n"},
+		{zarr.FormatOptions{Precision: new(0)}, "2,NaN,1235\n-0,0,0\n"},
+		{zarr.FormatOptions{Precision: new(3), Scientific: true, NaNString: ""}, "1.500e+00,NaN,1.235e+03\n-2.500e-01,0.000e+00,1.000e-07\n"},
+		{zarr.FormatOptions{Scientific: true}, "1.5e+00,NaN,1.2345677e+03\n-2.5e-01,0e+00,1e-07\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := reader.WithFormatOptions(tt.opts).WriteRegionCSV(ctx, []int{0, 0}, []int{2, 3}, &buf); err != nil {
+			t.Fatalf("WriteRegionCSV with %+v failed: %v", tt.opts, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%+v: expected %q, got %q", tt.opts, tt.want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package zarr
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"reflect"
+)
+
+// goTypes maps the dtype names returned by ParseDType to Go element types.
+var goTypes = map[string]reflect.Type{
+	"bool":       reflect.TypeOf(false),
+	"int8":       reflect.TypeOf(int8(0)),
+	"int16":      reflect.TypeOf(int16(0)),
+	"int32":      reflect.TypeOf(int32(0)),
+	"int64":      reflect.TypeOf(int64(0)),
+	"uint8":      reflect.TypeOf(uint8(0)),
+	"uint16":     reflect.TypeOf(uint16(0)),
+	"uint32":     reflect.TypeOf(uint32(0)),
+	"uint64":     reflect.TypeOf(uint64(0)),
+	"float32":    reflect.TypeOf(float32(0)),
+	"float64":    reflect.TypeOf(float64(0)),
+	"complex64":  reflect.TypeOf(complex64(0)),
+	"complex128": reflect.TypeOf(complex128(0)),
+}
+
+// DecodeElements decodes data holding elements of dtype d, in the given
+// byte order, into a new slice of the dtype's Go type, such as []float32
+// for "<f4" or ">f4". Data returned by a Reader is always little-endian;
+// bytes taken straight from storage are in the order DTypeByteOrder
+// reports. Every typed decode in the package goes through the same path.
+func DecodeElements(data []byte, d DType, order binary.ByteOrder) (any, error) {
+	typ := d.ReflectType()
+	if typ == nil {
+		return nil, fmt.Errorf("no Go type for dtype %s", d)
+	}
+	slice, err := decodeOrdered(data, typ, order)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Interface(), nil
+}
+
+// decodeSlice decodes little-endian element bytes into a new slice of the
+// given element type.
+func decodeSlice(data []byte, typ reflect.Type) (reflect.Value, error) {
+	return decodeOrdered(data, typ, binary.LittleEndian)
+}
+
+// decodeOrdered decodes element bytes in the given byte order into a new
+// slice of the given element type.
+func decodeOrdered(data []byte, typ reflect.Type, order binary.ByteOrder) (reflect.Value, error) {
+	size := int(typ.Size())
+	if len(data)%size != 0 {
+		return reflect.Value{}, fmt.Errorf("%d bytes is not a multiple of the %s item size", len(data), typ)
+	}
+
+	n := len(data) / size
+	slice := reflect.MakeSlice(reflect.SliceOf(typ), n, n)
+	if _, err := binary.Decode(data, order, slice.Interface()); err != nil {
+		return reflect.Value{}, fmt.Errorf("failed to decode %s elements: %w", typ, err)
+	}
+	return slice, nil
+}
+
+// SwapBytes reverses the byte order of every itemSize-byte element of data
+// in place, converting between big- and little-endian. Trailing bytes that
+// do not form a whole element are left alone, as is data with an item size
+// below 2. Complex values must be swapped per component, with half the item
+// size.
+//
+// Item sizes of 2, 4 and 8 are swapped a 64-bit word at a time; other sizes
+// fall back to swapping each element byte by byte.
+func SwapBytes(data []byte, itemSize int) {
+	if itemSize < 2 {
+		return
+	}
+	n := len(data) - len(data)%itemSize
+	done := 0
+	switch itemSize {
+	case 2:
+		for ; done+8 <= n; done += 8 {
+			x := binary.LittleEndian.Uint64(data[done:])
+			x = (x>>8)&0x00ff00ff00ff00ff | (x&0x00ff00ff00ff00ff)<<8
+			binary.LittleEndian.PutUint64(data[done:], x)
+		}
+	case 4:
+		for ; done+8 <= n; done += 8 {
+			x := binary.LittleEndian.Uint64(data[done:])
+			binary.LittleEndian.PutUint64(data[done:], bits.RotateLeft64(bits.ReverseBytes64(x), 32))
+		}
+	case 8:
+		for ; done+8 <= n; done += 8 {
+			x := binary.LittleEndian.Uint64(data[done:])
+			binary.LittleEndian.PutUint64(data[done:], bits.ReverseBytes64(x))
+		}
+	}
+	for off := done; off+itemSize <= n; off += itemSize {
+		elem := data[off : off+itemSize]
+		for i, j := 0, itemSize-1; i < j; i, j = i+1, j-1 {
+			elem[i], elem[j] = elem[j], elem[i]
+		}
+	}
+}
+
+// swapWidth returns the width at which chunk bytes must be swapped to turn
+// big-endian storage into the little-endian data the Reader returns, or 0
+// when no swap is needed. Raw dtype views keep the stored bytes.
+func (r *Reader) swapWidth() int {
+	dtype := r.meta.DType
+	if r.rawItemSize > 0 || len(dtype) < 3 || dtype[0] != '>' {
+		return 0
+	}
+	_, size, err := ParseDType(dtype)
+	if err != nil {
+		return 0
+	}
+	if dtype[1] == 'c' {
+		size /= 2
+	}
+	return size
+}
+
+// ReadRegionReflect reads a region like ReadRegion and returns it as a
+// reflect.Value wrapping a slice of the matching Go type (e.g. []float32 for
+// "<f4"), together with the shape of the region. Views created with
+// WithRawDType yield a slice of byte arrays of the raw item size.
+func (r *Reader) ReadRegionReflect(ctx context.Context, start, shape []int, opts ...ReadOption) (reflect.Value, []int, error) {
+	var typ reflect.Type
+	if r.rawItemSize > 0 {
+		typ = reflect.ArrayOf(r.rawItemSize, reflect.TypeOf(byte(0)))
+	} else {
+		name, _, err := ParseDType(r.meta.DType)
+		if err != nil {
+			return reflect.Value{}, nil, fmt.Errorf("invalid dtype: %w", err)
+		}
+		var ok bool
+		if typ, ok = goTypes[name]; !ok {
+			return reflect.Value{}, nil, fmt.Errorf("no Go type for dtype %s", name)
+		}
+	}
+
+	data, err := r.ReadRegion(ctx, start, shape, opts...)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+
+	slice, err := decodeSlice(data, typ)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	return slice, append([]int(nil), shape...), nil
+}
@@ -0,0 +1,270 @@
+package zarr_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+const int64Vector6 = `{
+	"zarr_format": 2,
+	"shape": [6],
+	"chunks": [4],
+	"dtype": "<i8",
+	"compressor": null,
+	"fill_value": 0,
+	"order": "C"
+}`
+
+func TestReader_ReadRegionReflect(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("float32", func(t *testing.T) {
+		reader := openSequential4x4(t)
+
+		value, shape, err := reader.ReadRegionReflect(ctx, []int{1, 1}, []int{2, 3})
+		if err != nil {
+			t.Fatalf("ReadRegionReflect failed: %v", err)
+		}
+		if value.Kind() != reflect.Slice || value.Type().Elem().Kind() != reflect.Float32 {
+			t.Fatalf("expected a []float32, got %v", value.Type())
+		}
+		if value.Len() != 6 {
+			t.Errorf("expected 6 elements, got %d", value.Len())
+		}
+		if !reflect.DeepEqual(shape, []int{2, 3}) {
+			t.Errorf("expected shape [2 3], got %v", shape)
+		}
+		if got := value.Interface().([]float32); !reflect.DeepEqual(got, []float32{5, 6, 7, 9, 10, 11}) {
+			t.Errorf("unexpected values %v", got)
+		}
+	})
+
+	t.Run("int64", func(t *testing.T) {
+		dir := t.TempDir()
+		writeArray(t, dir, int64Vector6, map[string][]byte{
+			"0": encodeLE(t, []int64{-1, 2, -3, 4}),
+			"1": encodeLE(t, []int64{1 << 40, -(1 << 40), 0, 0}),
+		})
+		reader := openReader(t, dir)
+
+		value, _, err := reader.ReadRegionReflect(ctx, []int{2}, []int{4})
+		if err != nil {
+			t.Fatalf("ReadRegionReflect failed: %v", err)
+		}
+		if value.Type().Elem().Kind() != reflect.Int64 {
+			t.Fatalf("expected a []int64, got %v", value.Type())
+		}
+		if got := value.Interface().([]int64); !reflect.DeepEqual(got, []int64{-3, 4, 1 << 40, -(1 << 40)}) {
+			t.Errorf("unexpected values %v", got)
+		}
+	})
+}
+
+// encodeBE encodes a slice of fixed-size values in big-endian order.
+func encodeBE(t *testing.T, values any) []byte {
+	t.Helper()
+
+	data, err := binary.Append(nil, binary.BigEndian, values)
+	if err != nil {
+		t.Fatalf("failed to encode %T: %v", values, err)
+	}
+	return data
+}
+
+func TestReader_BigEndian(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run(">f8", func(t *testing.T) {
+		dir := t.TempDir()
+		writeArray(t, dir, `{
+			"zarr_format": 2,
+			"shape": [6],
+			"chunks": [4],
+			"dtype": ">f8",
+			"compressor": null,
+			"fill_value": "NaN",
+			"order": "C"
+		}`, map[string][]byte{
+			"0": encodeBE(t, []float64{1.5, -2.25, 3e100, math.Pi}),
+		})
+		reader := openReader(t, dir)
+
+		chunk, err := reader.ReadChunk(ctx, []int{0})
+		if err != nil {
+			t.Fatalf("ReadChunk failed: %v", err)
+		}
+		if !bytes.Equal(chunk, encodeLE(t, []float64{1.5, -2.25, 3e100, math.Pi})) {
+			t.Errorf("expected ReadChunk to return little-endian values, got % x", chunk)
+		}
+
+		// Offset 1 goes through the range-read path for uncompressed chunks.
+		values, _, err := reader.ReadRegionReflect(ctx, []int{1}, []int{5})
+		if err != nil {
+			t.Fatalf("ReadRegionReflect failed: %v", err)
+		}
+		got := values.Interface().([]float64)
+		if got[0] != -2.25 || got[1] != 3e100 || got[2] != math.Pi {
+			t.Errorf("expected [-2.25 3e100 pi NaN NaN], got %v", got)
+		}
+		if !math.IsNaN(got[3]) || !math.IsNaN(got[4]) {
+			t.Errorf("expected the missing chunk to read as NaN, got %v", got)
+		}
+	})
+
+	t.Run(">i2", func(t *testing.T) {
+		var gz bytes.Buffer
+		w := gzip.NewWriter(&gz)
+		if _, err := w.Write(encodeBE(t, []int16{-300, 2, 1000, -1})); err != nil {
+			t.Fatalf("failed to compress chunk: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed to compress chunk: %v", err)
+		}
+
+		dir := t.TempDir()
+		writeArray(t, dir, `{
+			"zarr_format": 2,
+			"shape": [2, 2],
+			"chunks": [2, 2],
+			"dtype": ">i2",
+			"compressor": {"id": "gzip"},
+			"fill_value": 0,
+			"order": "C"
+		}`, map[string][]byte{"0.0": gz.Bytes()})
+		reader := openReader(t, dir)
+
+		values, _, err := reader.ReadRegionReflect(ctx, []int{0, 0}, []int{2, 2})
+		if err != nil {
+			t.Fatalf("ReadRegionReflect failed: %v", err)
+		}
+		want := []int16{-300, 2, 1000, -1}
+		if got := values.Interface().([]int16); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+}
+
+func TestDecodeElements(t *testing.T) {
+	values := []float32{1.5, -2, float32(math.Inf(1)), 1e-30}
+	stored := map[string][]byte{
+		"<f4": encodeLE(t, values),
+		">f4": encodeBE(t, values),
+	}
+
+	ctx := context.Background()
+	for dtype, chunk := range stored {
+		order, err := zarr.DTypeByteOrder(dtype)
+		if err != nil {
+			t.Fatalf("DTypeByteOrder(%s) failed: %v", dtype, err)
+		}
+		got, err := zarr.DecodeElements(chunk, zarr.DType(dtype), order)
+		if err != nil {
+			t.Fatalf("DecodeElements(%s) failed: %v", dtype, err)
+		}
+		if !slices.Equal(got.([]float32), values) {
+			t.Errorf("%s: expected %v, got %v", dtype, values, got)
+		}
+
+		// The reader returns little-endian data whatever the stored order.
+		dir := t.TempDir()
+		writeArray(t, dir, `{
+			"zarr_format": 2,
+			"shape": [4],
+			"chunks": [4],
+			"dtype": "`+dtype+`",
+			"compressor": null,
+			"fill_value": 0.0,
+			"order": "C"
+		}`, map[string][]byte{"0": chunk})
+		data, err := openReader(t, dir).ReadFull(ctx)
+		if err != nil {
+			t.Fatalf("%s: ReadFull failed: %v", dtype, err)
+		}
+		got, err = zarr.DecodeElements(data, zarr.DType(dtype), binary.LittleEndian)
+		if err != nil {
+			t.Fatalf("DecodeElements of %s reader output failed: %v", dtype, err)
+		}
+		if !slices.Equal(got.([]float32), values) {
+			t.Errorf("%s reader output: expected %v, got %v", dtype, values, got)
+		}
+	}
+
+	complexes, err := zarr.DecodeElements(encodeBE(t, []float64{1, -1, 0.5, 2}), ">c16", binary.BigEndian)
+	if err != nil {
+		t.Fatalf("DecodeElements(>c16) failed: %v", err)
+	}
+	if want := []complex128{complex(1, -1), complex(0.5, 2)}; !slices.Equal(complexes.([]complex128), want) {
+		t.Errorf("expected %v, got %v", want, complexes)
+	}
+	if _, err := zarr.DecodeElements(make([]byte, 6), "<f4", binary.LittleEndian); err == nil {
+		t.Error("expected a partial element to be rejected")
+	}
+	if _, err := zarr.DecodeElements(make([]byte, 4), "|S4", binary.LittleEndian); err == nil {
+		t.Error("expected a string dtype to be rejected")
+	}
+}
+
+func TestSwapBytes(t *testing.T) {
+	data := []byte{1, 2, 3, 4, 5, 6, 7}
+	zarr.SwapBytes(data, 2)
+	want := []byte{2, 1, 4, 3, 6, 5, 7}
+	if !bytes.Equal(data, want) {
+		t.Errorf("expected %v, got %v", want, data)
+	}
+
+	zarr.SwapBytes(data, 1)
+	if !bytes.Equal(data, want) {
+		t.Errorf("expected an item size of 1 to leave the data alone, got %v", data)
+	}
+}
+
+// swapNaive is the element-by-element reference for SwapBytes.
+func swapNaive(data []byte, itemSize int) {
+	for off := 0; off+itemSize <= len(data); off += itemSize {
+		slices.Reverse(data[off : off+itemSize])
+	}
+}
+
+func TestSwapBytes_MatchesNaive(t *testing.T) {
+	for _, itemSize := range []int{2, 3, 4, 8, 16} {
+		for _, n := range []int{0, 1, 7, 8, 9, 31, 64, 101} {
+			data := make([]byte, n)
+			for i := range data {
+				data[i] = byte(i*7 + 3)
+			}
+			want := bytes.Clone(data)
+			swapNaive(want, itemSize)
+			zarr.SwapBytes(data, itemSize)
+			if !bytes.Equal(data, want) {
+				t.Errorf("item size %d, %d bytes: expected %v, got %v", itemSize, n, want, data)
+			}
+		}
+	}
+}
+
+func BenchmarkSwapBytes(b *testing.B) {
+	data := make([]byte, 1<<20)
+	for _, itemSize := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("naive/%d", itemSize), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for b.Loop() {
+				swapNaive(data, itemSize)
+			}
+		})
+		b.Run(fmt.Sprintf("word/%d", itemSize), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for b.Loop() {
+				zarr.SwapBytes(data, itemSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package zarr
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"gocloud.dev/gcerrors"
+)
+
+// Deduplicated arrays store each distinct chunk shared by several keys once,
+// as a content-addressed object under .zblobs/, named by the SHA-256 of its
+// stored bytes. The chunk keys sharing it hold a small reference entry: the
+// refMagic prefix followed by the blob's key relative to the array. A .zdedup
+// marker next to .zarray tells readers to resolve references. Other Zarr
+// implementations do not understand references, so deduplicated arrays are
+// only readable with this package.
+const (
+	dedupMarker = ".zdedup"
+	blobDir     = ".zblobs/"
+)
+
+// refMagic starts every reference entry. No stored chunk of a deduplicated
+// array may start with it.
+var refMagic = []byte("\x00zarr-chunk-ref\x00")
+
+// refSize is the exact size of a reference entry.
+var refSize = len(refMagic) + len(blobDir) + sha256.Size*2
+
+// dedupState tracks, for a Writer with deduplication enabled, which chunk
+// contents have been written and where.
+type dedupState struct {
+	mu sync.Mutex
+	// blobs holds the hashes stored as a blob.
+	blobs map[string]bool
+	// first maps the hash of a chunk written once, inline, to its key.
+	first map[string]string
+	// hashes maps each key written to the hash of its content.
+	hashes map[string]string
+}
+
+// EnableDedup turns on chunk deduplication for the rest of the writer's
+// lifetime: a chunk byte-identical to one written before, such as a
+// repeated all-constant chunk, is stored once and referenced from every key
+// holding it. Only chunks written through this Writer are compared.
+// Readers of this package resolve references transparently; other Zarr
+// implementations cannot read the array. EnableDedup writes the .zdedup
+// marker that tells readers to expect references.
+func (w *Writer) EnableDedup(ctx context.Context) error {
+	if err := w.store.bucket.WriteAll(ctx, w.prefix+dedupMarker, []byte(`{"version": 1}`), nil); err != nil {
+		return fmt.Errorf("failed to write %s: %w", dedupMarker, err)
+	}
+	w.dedup = &dedupState{blobs: map[string]bool{}, first: map[string]string{}, hashes: map[string]string{}}
+	w.reader.dedup = &dedupFlag{}
+	return nil
+}
+
+// writeDeduped stores an encoded chunk under key, as a reference if a chunk
+// with the same bytes has been written before. The first chunk with given
+// contents is stored inline; once a second key holds it too, the contents
+// move to a blob and both keys become references.
+func (w *Writer) writeDeduped(ctx context.Context, key string, encoded []byte) error {
+	if bytes.HasPrefix(encoded, refMagic) {
+		return fmt.Errorf("chunk %s starts with the dedup reference marker and cannot be stored", key)
+	}
+	sum := sha256.Sum256(encoded)
+	hash := hex.EncodeToString(sum[:])
+
+	d := w.dedup
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	first, seen := d.first[hash]
+	switch {
+	case d.blobs[hash]:
+	case seen && first != key && d.hashes[first] == hash:
+		if err := w.putObject(ctx, blobDir+hash, encoded); err != nil {
+			return err
+		}
+		if err := w.putObject(ctx, first, refEntry(hash)); err != nil {
+			return err
+		}
+		d.blobs[hash] = true
+		delete(d.first, hash)
+	default:
+		if err := w.putObject(ctx, key, encoded); err != nil {
+			return err
+		}
+		d.first[hash] = key
+		d.hashes[key] = hash
+		return nil
+	}
+	if err := w.putObject(ctx, key, refEntry(hash)); err != nil {
+		return err
+	}
+	d.hashes[key] = hash
+	return nil
+}
+
+// putObject writes data under name, relative to the array.
+func (w *Writer) putObject(ctx context.Context, name string, data []byte) error {
+	if err := w.store.bucket.WriteAll(ctx, w.prefix+name, data, nil); err != nil {
+		return fmt.Errorf("failed to write chunk %s: %w", name, err)
+	}
+	return nil
+}
+
+// refEntry returns the reference entry pointing at the blob with the given
+// hex hash.
+func refEntry(hash string) []byte {
+	return append(append(bytes.Clone(refMagic), blobDir...), hash...)
+}
+
+// refTarget returns the blob key, relative to the array, that data refers to
+// if it is a reference entry.
+func refTarget(data []byte) (string, bool) {
+	if len(data) != refSize || !bytes.HasPrefix(data, refMagic) {
+		return "", false
+	}
+	target := string(data[len(refMagic):])
+	if target[:len(blobDir)] != blobDir {
+		return "", false
+	}
+	if _, err := hex.DecodeString(target[len(blobDir):]); err != nil {
+		return "", false
+	}
+	return target, true
+}
+
+// dedupFlag caches whether an array is deduplicated. It is shared by a
+// Reader and its views.
+type dedupFlag struct {
+	once sync.Once
+	on   bool
+	err  error
+}
+
+// deduplicated reports whether the array has a .zdedup marker, checking the
+// bucket on first use.
+func (r *Reader) deduplicated(ctx context.Context) (bool, error) {
+	f := r.dedup
+	if f == nil {
+		return false, nil
+	}
+	f.once.Do(func() {
+		f.on, f.err = r.store.bucket.Exists(ctx, r.key(dedupMarker))
+		if f.err != nil {
+			f.err = fmt.Errorf("failed to check %s: %w", dedupMarker, f.err)
+		}
+	})
+	return f.on, f.err
+}
+
+// resolveRef returns the blob a reference entry stored under key points to,
+// or raw itself when it is not a reference or the array is not
+// deduplicated.
+func (r *Reader) resolveRef(ctx context.Context, key string, raw []byte) ([]byte, error) {
+	target, ok := refTarget(raw)
+	if !ok {
+		return raw, nil
+	}
+	if on, err := r.deduplicated(ctx); err != nil || !on {
+		return raw, err
+	}
+	data, err := r.readObject(ctx, target)
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil, fmt.Errorf("chunk %s: %w: referenced %s is missing", key, ErrChunkCorrupt, target)
+		}
+		return nil, err
+	}
+	r.buffers.put(raw)
+	return data, nil
+}
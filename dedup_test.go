@@ -0,0 +1,144 @@
+package zarr_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"gocloud.dev/blob"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestWriter_Dedup(t *testing.T) {
+	for _, compressor := range []*zarr.CompressorConfig{nil, {ID: "zlib", Level: 1}} {
+		name := "raw"
+		if compressor != nil {
+			name = compressor.ID
+		}
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			fb := newFakeBucket(nil)
+			store := zarr.NewSharedBucket(blob.NewBucket(fb))
+			defer store.Close()
+			w, err := store.CreateArray(ctx, "a", &zarr.Metadata{
+				Shape: []int{8}, Chunks: []int{2}, DType: ">f4", FillValue: 0.0, Compressor: compressor,
+			})
+			if err != nil {
+				t.Fatalf("CreateArray failed: %v", err)
+			}
+			defer w.Close()
+			if err := w.EnableDedup(ctx); err != nil {
+				t.Fatalf("EnableDedup failed: %v", err)
+			}
+
+			// Chunks 0, 1 and 3 are identical, and not the fill value.
+			values := []float32{1, 1, 1, 1, 2, 3, 1, 1}
+			if err := w.WriteRegion(ctx, []int{0}, []int{8}, encodeLE(t, values)); err != nil {
+				t.Fatalf("WriteRegion failed: %v", err)
+			}
+
+			// The repeated contents are stored once, as a blob.
+			var blobs []string
+			for key := range fb.objects {
+				if strings.HasPrefix(key, "a/.zblobs/") {
+					blobs = append(blobs, key)
+				}
+			}
+			if len(blobs) != 1 {
+				t.Fatalf("expected one blob, got %v", blobs)
+			}
+			blobData, _ := fb.get(blobs[0])
+			for _, key := range []string{"0", "1", "3"} {
+				if data, _ := fb.get("a/" + key); bytes.Equal(data, blobData) || len(data) >= 100 {
+					t.Errorf("expected chunk %s to be a short reference, got %d bytes", key, len(data))
+				}
+			}
+			if data, _ := fb.get("a/2"); len(data) == 0 || bytes.Equal(data, blobData) {
+				t.Error("expected the unique chunk 2 to be stored inline")
+			}
+
+			reader := openFakeArray(t, fb, "a")
+			check := func(want []float32) {
+				t.Helper()
+				data, err := reader.ReadFull(ctx)
+				if err != nil {
+					t.Fatalf("ReadFull failed: %v", err)
+				}
+				if got := decodeFloat32(data); !slices.Equal(got, want) {
+					t.Errorf("expected %v, got %v", want, got)
+				}
+				// Partial reads of uncompressed chunks must not read byte
+				// ranges of a reference entry.
+				data, err = reader.ReadRegion(ctx, []int{3}, []int{3})
+				if err != nil {
+					t.Fatalf("ReadRegion failed: %v", err)
+				}
+				if got := decodeFloat32(data); !slices.Equal(got, want[3:6]) {
+					t.Errorf("expected %v, got %v", want[3:6], got)
+				}
+			}
+			check(values)
+
+			// Updating one element of a shared chunk leaves the others alone.
+			if err := w.WriteRegion(ctx, []int{1}, []int{1}, encodeLE(t, []float32{9})); err != nil {
+				t.Fatalf("WriteRegion failed: %v", err)
+			}
+			values[1] = 9
+			check(values)
+
+			// Copies hold the chunks themselves.
+			dir := t.TempDir()
+			if err := reader.CopyTo(ctx, "file:///"+filepath.ToSlash(dir), zarr.CopyOptions{}); err != nil {
+				t.Fatalf("CopyTo failed: %v", err)
+			}
+			data, err := openReader(t, dir).ReadFull(ctx)
+			if err != nil {
+				t.Fatalf("ReadFull on the copy failed: %v", err)
+			}
+			if got := decodeFloat32(data); !slices.Equal(got, values) {
+				t.Errorf("expected the copy to hold %v, got %v", values, got)
+			}
+		})
+	}
+}
+
+func TestWriter_DedupArchive(t *testing.T) {
+	ctx := context.Background()
+	fb := newFakeBucket(nil)
+	store := zarr.NewSharedBucket(blob.NewBucket(fb))
+	defer store.Close()
+	w, err := store.CreateArray(ctx, "", &zarr.Metadata{
+		Shape: []int{6}, Chunks: []int{2}, DType: "<i4", FillValue: 0,
+	})
+	if err != nil {
+		t.Fatalf("CreateArray failed: %v", err)
+	}
+	defer w.Close()
+	if err := w.EnableDedup(ctx); err != nil {
+		t.Fatalf("EnableDedup failed: %v", err)
+	}
+	values := []int32{5, 6, 5, 6, 5, 6}
+	if err := w.WriteRegion(ctx, []int{0}, []int{6}, encodeLE(t, values)); err != nil {
+		t.Fatalf("WriteRegion failed: %v", err)
+	}
+
+	var archive bytes.Buffer
+	if err := openFake(t, fb).ExportArchive(ctx, &archive); err != nil {
+		t.Fatalf("ExportArchive failed: %v", err)
+	}
+	dir := t.TempDir()
+	if err := zarr.ImportArchive(ctx, &archive, "file:///"+filepath.ToSlash(dir)); err != nil {
+		t.Fatalf("ImportArchive failed: %v", err)
+	}
+	got, err := zarr.ReadFullAs[int32](ctx, openReader(t, dir))
+	if err != nil {
+		t.Fatalf("ReadFullAs failed: %v", err)
+	}
+	if !slices.Equal(got, values) {
+		t.Errorf("expected %v, got %v", values, got)
+	}
+}
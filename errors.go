@@ -0,0 +1,24 @@
+package zarr
+
+import "errors"
+
+var (
+	// ErrChunkNotFound is returned when a chunk is absent from the store and
+	// the read uses the NotFoundError policy.
+	ErrChunkNotFound = errors.New("chunk not found")
+
+	// ErrChunkCorrupt is returned when a chunk fails an integrity check.
+	ErrChunkCorrupt = errors.New("chunk corrupt")
+
+	// ErrArrayTooLarge is returned when the byte size of a read does not fit
+	// in memory addressable by this platform.
+	ErrArrayTooLarge = errors.New("array too large")
+
+	// ErrReadLimitExceeded is returned when a read would produce more bytes
+	// than allowed by WithMaxReadBytes.
+	ErrReadLimitExceeded = errors.New("read exceeds the configured byte limit")
+
+	// ErrTooManyChunks is returned when a read would touch more chunks than
+	// allowed by WithMaxChunksPerRead.
+	ErrTooManyChunks = errors.New("read touches too many chunks")
+)
@@ -0,0 +1,279 @@
+package zarr_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/driver"
+	"gocloud.dev/gcerrors"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+// fakeRead records one NewRangeReader call made against a fakeBucket.
+type fakeRead struct {
+	Key    string
+	Offset int64
+	Length int64
+}
+
+// fakeBucket is an in-memory blob driver that records reads and lets tests
+// inject delays or failures.
+type fakeBucket struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	reads   []fakeRead
+	// open counts readers that have been opened but not closed yet.
+	open int
+
+	// beforeRead, if set, runs before every object read. A non-nil error
+	// fails the read.
+	beforeRead func(ctx context.Context, key string) error
+}
+
+func newFakeBucket(objects map[string][]byte) *fakeBucket {
+	if objects == nil {
+		objects = map[string][]byte{}
+	}
+	return &fakeBucket{objects: objects}
+}
+
+// openFake opens a Reader on the root of a fake bucket.
+func openFake(t testing.TB, fb *fakeBucket) *zarr.Reader {
+	t.Helper()
+	return openFakeArray(t, fb, "")
+}
+
+// openFakeArray opens a Reader on the array stored under path in a fake
+// bucket.
+func openFakeArray(t testing.TB, fb *fakeBucket, path string) *zarr.Reader {
+	t.Helper()
+
+	store := zarr.NewSharedBucket(blob.NewBucket(fb))
+	reader, err := store.OpenArray(context.Background(), path)
+	store.Close()
+	if err != nil {
+		t.Fatalf("OpenArray on fake bucket failed: %v", err)
+	}
+	t.Cleanup(func() { reader.Close() })
+	fb.resetReads()
+	return reader
+}
+
+func (b *fakeBucket) put(key string, data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.objects[key] = data
+}
+
+func (b *fakeBucket) get(key string) ([]byte, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	data, ok := b.objects[key]
+	return data, ok
+}
+
+// readsOf returns the recorded reads of the given key.
+func (b *fakeBucket) readsOf(key string) []fakeRead {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var out []fakeRead
+	for _, r := range b.reads {
+		if r.Key == key {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func (b *fakeBucket) readCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.reads)
+}
+
+// openReaders returns the number of object readers not closed yet.
+func (b *fakeBucket) openReaders() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.open
+}
+
+func (b *fakeBucket) resetReads() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reads = nil
+}
+
+func (b *fakeBucket) ErrorCode(err error) gcerrors.ErrorCode {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return gcerrors.NotFound
+	case errors.Is(err, context.Canceled):
+		return gcerrors.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return gcerrors.DeadlineExceeded
+	default:
+		return gcerrors.Unknown
+	}
+}
+
+func (b *fakeBucket) As(any) bool             { return false }
+func (b *fakeBucket) ErrorAs(error, any) bool { return false }
+func (b *fakeBucket) Close() error            { return nil }
+
+func (b *fakeBucket) Attributes(ctx context.Context, key string) (*driver.Attributes, error) {
+	data, ok := b.get(key)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	sum := md5.Sum(data)
+	return &driver.Attributes{
+		ContentType: "application/octet-stream",
+		Size:        int64(len(data)),
+		MD5:         sum[:],
+	}, nil
+}
+
+func (b *fakeBucket) ListPaged(ctx context.Context, opts *driver.ListOptions) (*driver.ListPage, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	keys := make([]string, 0, len(b.objects))
+	for key := range b.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	page := &driver.ListPage{}
+	seenDirs := map[string]bool{}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, opts.Prefix) {
+			continue
+		}
+		if opts.Delimiter != "" {
+			rest := key[len(opts.Prefix):]
+			if i := strings.Index(rest, opts.Delimiter); i >= 0 {
+				dir := opts.Prefix + rest[:i+len(opts.Delimiter)]
+				if !seenDirs[dir] {
+					seenDirs[dir] = true
+					page.Objects = append(page.Objects, &driver.ListObject{Key: dir, IsDir: true})
+				}
+				continue
+			}
+		}
+		data := b.objects[key]
+		sum := md5.Sum(data)
+		page.Objects = append(page.Objects, &driver.ListObject{
+			Key:  key,
+			Size: int64(len(data)),
+			MD5:  sum[:],
+		})
+	}
+	return page, nil
+}
+
+func (b *fakeBucket) NewRangeReader(ctx context.Context, key string, offset, length int64, opts *driver.ReaderOptions) (driver.Reader, error) {
+	b.mu.Lock()
+	b.reads = append(b.reads, fakeRead{Key: key, Offset: offset, Length: length})
+	hook := b.beforeRead
+	b.mu.Unlock()
+
+	if hook != nil {
+		if err := hook(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+
+	data, ok := b.get(key)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	data = data[offset:]
+	if length >= 0 && length < int64(len(data)) {
+		data = data[:length]
+	}
+	b.mu.Lock()
+	b.open++
+	b.mu.Unlock()
+	return &fakeReader{
+		bucket: b,
+		Reader: bytes.NewReader(data),
+		attrs:  driver.ReaderAttributes{ContentType: "application/octet-stream", Size: int64(len(data))},
+	}, nil
+}
+
+func (b *fakeBucket) NewTypedWriter(ctx context.Context, key, contentType string, opts *driver.WriterOptions) (driver.Writer, error) {
+	return &fakeWriter{bucket: b, key: key}, nil
+}
+
+func (b *fakeBucket) Copy(ctx context.Context, dstKey, srcKey string, opts *driver.CopyOptions) error {
+	data, ok := b.get(srcKey)
+	if !ok {
+		return os.ErrNotExist
+	}
+	b.put(dstKey, append([]byte(nil), data...))
+	return nil
+}
+
+func (b *fakeBucket) Delete(ctx context.Context, key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.objects[key]; !ok {
+		return os.ErrNotExist
+	}
+	delete(b.objects, key)
+	return nil
+}
+
+func (b *fakeBucket) SignedURL(ctx context.Context, key string, opts *driver.SignedURLOptions) (string, error) {
+	return "", errors.ErrUnsupported
+}
+
+type fakeReader struct {
+	*bytes.Reader
+	bucket *fakeBucket
+	attrs  driver.ReaderAttributes
+}
+
+func (r *fakeReader) Close() error {
+	r.bucket.mu.Lock()
+	r.bucket.open--
+	r.bucket.mu.Unlock()
+	return nil
+}
+
+func (r *fakeReader) As(any) bool                          { return false }
+func (r *fakeReader) Attributes() *driver.ReaderAttributes { return &r.attrs }
+
+type fakeWriter struct {
+	bucket *fakeBucket
+	key    string
+	buf    bytes.Buffer
+}
+
+func (w *fakeWriter) Write(p []byte) (int, error) { return w.buf.Write(p) }
+
+func (w *fakeWriter) Close() error {
+	w.bucket.put(w.key, w.buf.Bytes())
+	return nil
+}
+
+// Ensure the fakes satisfy the driver interfaces.
+var (
+	_ driver.Bucket = (*fakeBucket)(nil)
+	_ driver.Reader = (*fakeReader)(nil)
+	_ io.Writer     = (*fakeWriter)(nil)
+)
@@ -0,0 +1,275 @@
+package zarr
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// fillFloat64 converts a fill_value to float64. It accepts the json.Number
+// values LoadMetadata decodes, Go integers and floats set on a Metadata in
+// code, the "NaN", "Infinity" and "-Infinity" strings numpy writes for
+// non-finite floats, and booleans as 0 or 1.
+func fillFloat64(v any) (float64, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		switch v {
+		case "NaN":
+			return math.NaN(), true
+		case "Infinity":
+			return math.Inf(1), true
+		case "-Infinity":
+			return math.Inf(-1), true
+		}
+		return 0, false
+	}
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanFloat():
+		return rv.Float(), true
+	case rv.CanInt():
+		return float64(rv.Int()), true
+	case rv.CanUint():
+		return float64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+// errFillRange reports an integer fill value that does not fit the dtype.
+var errFillRange = errors.New("out of range")
+
+// fillInt converts a fill_value to the two's complement bits of an integer
+// of the given kind ('b', 'i' or 'u') and size in bytes. Integers are
+// parsed exactly, so that sentinels such as the largest int64 or uint64
+// survive; floats must hold an integer value.
+func fillInt(v any, kind byte, size int) (uint64, error) {
+	bits := size * 8
+	switch v := v.(type) {
+	case json.Number:
+		var err error
+		if kind == 'i' {
+			var i int64
+			if i, err = strconv.ParseInt(string(v), 10, bits); err == nil {
+				return uint64(i), nil
+			}
+		} else {
+			var u uint64
+			if u, err = strconv.ParseUint(string(v), 10, bits); err == nil {
+				return u, nil
+			}
+		}
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, errFillRange
+		}
+		// Not an integer literal, such as "1e3" or "-1" for an unsigned
+		// dtype: go through the float value.
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanInt():
+		i := rv.Int()
+		if kind == 'i' {
+			if bits < 64 && (i < -1<<(bits-1) || i >= 1<<(bits-1)) {
+				return 0, errFillRange
+			}
+			return uint64(i), nil
+		}
+		if i < 0 {
+			return 0, errFillRange
+		}
+		return uintInRange(uint64(i), bits)
+	case rv.CanUint():
+		u := rv.Uint()
+		if kind == 'i' && u >= 1<<(bits-1) {
+			return 0, errFillRange
+		}
+		return uintInRange(u, bits)
+	}
+
+	f, ok := fillFloat64(v)
+	if !ok || f != math.Trunc(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("not an integer")
+	}
+	if kind == 'i' {
+		lo, hi := -math.Ldexp(1, bits-1), math.Ldexp(1, bits-1)
+		if f < lo || f >= hi {
+			return 0, errFillRange
+		}
+		return uint64(int64(f)), nil
+	}
+	if f < 0 || f >= math.Ldexp(1, bits) {
+		return 0, errFillRange
+	}
+	return uint64(f), nil
+}
+
+// uintInRange returns u if it fits in an unsigned integer of the given
+// number of bits.
+func uintInRange(u uint64, bits int) (uint64, error) {
+	if bits < 64 && u >= 1<<bits {
+		return 0, errFillRange
+	}
+	return u, nil
+}
+
+// fillParts splits a complex fill_value into its real and imaginary parts,
+// given either as a [real, imag] pair or as a Go complex number.
+func fillParts(v any) ([]any, bool) {
+	switch v := v.(type) {
+	case []any:
+		return v, len(v) == 2
+	case complex128:
+		return []any{real(v), imag(v)}, true
+	case complex64:
+		return []any{real(v), imag(v)}, true
+	}
+	rv := reflect.ValueOf(v)
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Len() == 2 {
+		return []any{rv.Index(0).Interface(), rv.Index(1).Interface()}, true
+	}
+	return nil, false
+}
+
+// encodeFill returns the little-endian bytes of a single element of the
+// given dtype holding fill, or nil when the fill value is null or encodes as
+// all zeros, so that callers can keep clearing buffers in that case. Complex
+// fill values are [real, imag] pairs.
+func encodeFill(dtype string, fill any) ([]byte, error) {
+	if fill == nil {
+		return nil, nil
+	}
+	_, size, err := ParseDType(dtype)
+	if err != nil {
+		// Raw and object dtypes have no byte-level fill.
+		return nil, nil
+	}
+
+	buf := make([]byte, size)
+	switch kind := dtype[1]; kind {
+	case 'b', 'i', 'u':
+		v, err := fillInt(fill, kind, size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fill_value %v for dtype %s: %w", fill, dtype, err)
+		}
+		putUint(buf, v)
+	case 'f':
+		f, ok := fillFloat64(fill)
+		if !ok {
+			return nil, fmt.Errorf("invalid fill_value %v for dtype %s", fill, dtype)
+		}
+		if err := putFloat(buf, f); err != nil {
+			return nil, fmt.Errorf("invalid fill_value %v for dtype %s: %w", fill, dtype, err)
+		}
+	case 'c':
+		parts, ok := fillParts(fill)
+		if !ok {
+			return nil, fmt.Errorf("invalid fill_value %v for dtype %s, expected [real, imag]", fill, dtype)
+		}
+		for i, p := range parts {
+			f, ok := fillFloat64(p)
+			if !ok {
+				return nil, fmt.Errorf("invalid fill_value %v for dtype %s", fill, dtype)
+			}
+			if err := putFloat(buf[i*size/2:(i+1)*size/2], f); err != nil {
+				return nil, fmt.Errorf("invalid fill_value %v for dtype %s: %w", fill, dtype, err)
+			}
+		}
+	}
+
+	for _, b := range buf {
+		if b != 0 {
+			return buf, nil
+		}
+	}
+	return nil, nil
+}
+
+func putUint(buf []byte, v uint64) {
+	for i := range buf {
+		buf[i] = byte(v >> (8 * i))
+	}
+}
+
+func putFloat(buf []byte, f float64) error {
+	switch len(buf) {
+	case 2:
+		binary.LittleEndian.PutUint16(buf, float16bits(float32(f)))
+	case 4:
+		binary.LittleEndian.PutUint32(buf, math.Float32bits(float32(f)))
+	case 8:
+		binary.LittleEndian.PutUint64(buf, math.Float64bits(f))
+	default:
+		return fmt.Errorf("unsupported float size %d", len(buf))
+	}
+	return nil
+}
+
+// float16bits converts f to IEEE 754 half precision, rounding half away from
+// zero and saturating to infinity.
+func float16bits(f float32) uint16 {
+	b := math.Float32bits(f)
+	sign := uint16(b>>16) & 0x8000
+	exp := int(b>>23) & 0xff
+	mant := b & 0x7fffff
+	if exp == 0xff {
+		if mant != 0 {
+			return sign | 0x7e00
+		}
+		return sign | 0x7c00
+	}
+
+	e := exp - 127 + 15
+	switch {
+	case e >= 0x1f:
+		return sign | 0x7c00
+	case e <= 0:
+		// Subnormal half, or zero when too small.
+		if e < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint(14 - e)
+		h := uint16(mant >> shift)
+		if mant>>(shift-1)&1 != 0 {
+			h++
+		}
+		return sign | h
+	}
+	h := sign | uint16(e<<10) | uint16(mant>>13)
+	if mant&0x1000 != 0 {
+		h++
+	}
+	return h
+}
+
+// fillChunk sets every element of buf to the array's fill value. buf must
+// start at an element boundary.
+func (r *Reader) fillChunk(buf []byte) {
+	n := len(r.fill)
+	if n == 0 || r.rawItemSize > 0 {
+		clear(buf)
+		return
+	}
+	copy(buf, r.fill)
+	for filled := n; filled < len(buf); filled *= 2 {
+		copy(buf[filled:], buf[:filled])
+	}
+}
@@ -0,0 +1,166 @@
+package zarr_test
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func fillArray(dtype, fill string) string {
+	return `{
+		"zarr_format": 2,
+		"shape": [4, 4],
+		"chunks": [2, 2],
+		"dtype": "` + dtype + `",
+		"compressor": null,
+		"fill_value": ` + fill + `,
+		"order": "C"
+	}`
+}
+
+func TestReader_FillValueNaN(t *testing.T) {
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, fillArray("<f4", `"NaN"`), map[string][]float32{
+		"0.0": {1, 2, 3, 4},
+	})
+	reader := openReader(t, dir)
+	ctx := context.Background()
+
+	chunk, err := reader.ReadChunk(ctx, []int{1, 1})
+	if err != nil {
+		t.Fatalf("ReadChunk failed: %v", err)
+	}
+	for i, v := range decodeFloat32(chunk) {
+		if !math.IsNaN(float64(v)) {
+			t.Errorf("missing chunk element %d: expected NaN, got %v", i, v)
+		}
+	}
+
+	full, err := reader.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+	got := decodeFloat32(full)
+	if got[0] != 1 || got[5] != 4 {
+		t.Errorf("expected the stored chunk to be kept, got %v", got)
+	}
+	if !math.IsNaN(float64(got[2])) || !math.IsNaN(float64(got[15])) {
+		t.Errorf("expected NaN outside the stored chunk, got %v", got)
+	}
+
+	// A region inside one missing chunk goes through the range-read path.
+	region, err := reader.ReadRegion(ctx, []int{2, 3}, []int{1, 1})
+	if err != nil {
+		t.Fatalf("ReadRegion failed: %v", err)
+	}
+	if v := decodeFloat32(region)[0]; !math.IsNaN(float64(v)) {
+		t.Errorf("expected NaN from ReadRegion, got %v", v)
+	}
+}
+
+func TestReader_FillValueTyped(t *testing.T) {
+	tests := []struct {
+		dtype string
+		fill  string
+		check func(t *testing.T, elem []byte)
+	}{
+		{"<i4", "-9999", func(t *testing.T, elem []byte) {
+			if v := int32(binary.LittleEndian.Uint32(elem)); v != -9999 {
+				t.Errorf("expected -9999, got %d", v)
+			}
+		}},
+		{"<u2", "65535", func(t *testing.T, elem []byte) {
+			if v := binary.LittleEndian.Uint16(elem); v != 65535 {
+				t.Errorf("expected 65535, got %d", v)
+			}
+		}},
+		{"<f8", `"-Infinity"`, func(t *testing.T, elem []byte) {
+			if v := math.Float64frombits(binary.LittleEndian.Uint64(elem)); !math.IsInf(v, -1) {
+				t.Errorf("expected -Inf, got %v", v)
+			}
+		}},
+		{"<f2", "1.5", func(t *testing.T, elem []byte) {
+			if v := binary.LittleEndian.Uint16(elem); v != 0x3e00 {
+				t.Errorf("expected half-precision 1.5 (0x3e00), got %#x", v)
+			}
+		}},
+		{"|b1", "true", func(t *testing.T, elem []byte) {
+			if elem[0] != 1 {
+				t.Errorf("expected true, got %d", elem[0])
+			}
+		}},
+		{"<c8", "[1.0, \"NaN\"]", func(t *testing.T, elem []byte) {
+			re := math.Float32frombits(binary.LittleEndian.Uint32(elem))
+			im := math.Float32frombits(binary.LittleEndian.Uint32(elem[4:]))
+			if re != 1 || !math.IsNaN(float64(im)) {
+				t.Errorf("expected (1+NaNi), got (%v+%vi)", re, im)
+			}
+		}},
+		{"<i8", "9223372036854775807", func(t *testing.T, elem []byte) {
+			if v := int64(binary.LittleEndian.Uint64(elem)); v != math.MaxInt64 {
+				t.Errorf("expected %d, got %d", int64(math.MaxInt64), v)
+			}
+		}},
+		{"<u8", "18446744073709551615", func(t *testing.T, elem []byte) {
+			if v := binary.LittleEndian.Uint64(elem); v != math.MaxUint64 {
+				t.Errorf("expected %d, got %d", uint64(math.MaxUint64), v)
+			}
+		}},
+		{"<i8", "9007199254740993", func(t *testing.T, elem []byte) {
+			if v := int64(binary.LittleEndian.Uint64(elem)); v != 1<<53+1 {
+				t.Errorf("expected %d, got %d", 1<<53+1, v)
+			}
+		}},
+		{">i8", "-9223372036854775808", func(t *testing.T, elem []byte) {
+			if v := int64(binary.LittleEndian.Uint64(elem)); v != math.MinInt64 {
+				t.Errorf("expected %d, got %d", int64(math.MinInt64), v)
+			}
+		}},
+		{"<i4", "null", func(t *testing.T, elem []byte) {
+			if v := binary.LittleEndian.Uint32(elem); v != 0 {
+				t.Errorf("expected a null fill value to read as 0, got %d", v)
+			}
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.dtype+" "+tc.fill, func(t *testing.T) {
+			dir := t.TempDir()
+			writeArray(t, dir, fillArray(tc.dtype, tc.fill), nil)
+			reader := openReader(t, dir)
+
+			data, err := reader.ReadFull(context.Background())
+			if err != nil {
+				t.Fatalf("ReadFull failed: %v", err)
+			}
+			_, size, _ := zarr.ParseDType(tc.dtype)
+			if len(data) != 16*size {
+				t.Fatalf("expected %d bytes, got %d", 16*size, len(data))
+			}
+			tc.check(t, data[:size])
+			tc.check(t, data[len(data)-size:])
+		})
+	}
+}
+
+func TestNewReader_InvalidFillValue(t *testing.T) {
+	for _, tc := range []struct{ dtype, fill string }{
+		{"<i2", "1.5"},
+		{"<u1", "-1"},
+		{"<i1", "300"},
+		{"<i8", "9223372036854775808"},
+		{"<u8", "18446744073709551616"},
+		{"<f4", `"bogus"`},
+	} {
+		dir := t.TempDir()
+		writeArray(t, dir, fillArray(tc.dtype, tc.fill), nil)
+		reader, err := zarr.NewReader(context.Background(), "file:///"+filepath.ToSlash(dir))
+		if err == nil {
+			reader.Close()
+			t.Errorf("expected fill_value %s to be rejected for %s", tc.fill, tc.dtype)
+		}
+	}
+}
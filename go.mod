@@ -3,8 +3,13 @@ module github.com/TuSKan/go-zarr
 go 1.26
 
 require (
+	github.com/andybalholm/brotli v1.2.0
+	github.com/apache/arrow-go/v18 v18.4.1
+	github.com/klauspost/compress v1.18.4
 	github.com/mrjoshuak/go-blosc v1.0.2
+	github.com/pierrec/lz4/v4 v4.1.23
 	gocloud.dev v0.44.0
+	golang.org/x/sync v0.16.0
 )
 
 replace github.com/mrjoshuak/go-blosc => github.com/TuSKan/go-blosc v0.0.0-20260225030303-38a53cc4b92b
@@ -12,23 +17,26 @@ replace github.com/mrjoshuak/go-blosc => github.com/TuSKan/go-blosc v0.0.0-20260
 require (
 	github.com/go-logr/logr v1.4.3 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/goccy/go-json v0.10.5 // indirect
+	github.com/google/flatbuffers v25.2.10+incompatible // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/googleapis/gax-go/v2 v2.15.0 // indirect
-	github.com/klauspost/compress v1.18.4 // indirect
-	github.com/pierrec/lz4/v4 v4.1.23 // indirect
+	github.com/klauspost/cpuid/v2 v2.3.0 // indirect
 	github.com/stretchr/testify v1.11.1 // indirect
+	github.com/zeebo/xxh3 v1.0.2 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.opentelemetry.io/otel v1.37.0 // indirect
 	go.opentelemetry.io/otel/metric v1.37.0 // indirect
 	go.opentelemetry.io/otel/sdk v1.37.0 // indirect
 	go.opentelemetry.io/otel/sdk/metric v1.37.0 // indirect
 	go.opentelemetry.io/otel/trace v1.37.0 // indirect
+	golang.org/x/exp v0.0.0-20250408133849-7e4ce0ab07d0 // indirect
 	golang.org/x/net v0.43.0 // indirect
 	golang.org/x/sys v0.39.0 // indirect
 	golang.org/x/text v0.28.0 // indirect
 	golang.org/x/xerrors v0.0.0-20240903120638-7835f813f4da // indirect
 	google.golang.org/api v0.247.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250811230008-5f3141c8851a // indirect
-	google.golang.org/grpc v1.74.2 // indirect
+	google.golang.org/grpc v1.75.0 // indirect
 	google.golang.org/protobuf v1.36.10 // indirect
 )
@@ -0,0 +1,132 @@
+package zarr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gocloud.dev/blob"
+)
+
+// Group is a Zarr V2 group: a key prefix holding a .zgroup file, under which
+// arrays and further groups are stored.
+type Group struct {
+	store  *SharedBucket
+	ref    *bucketRef
+	prefix string
+}
+
+// OpenGroup opens the bucket at the given gocloud URL and the group at its
+// root. Closing the group closes the bucket once every array and sub-group
+// opened from it has been closed too.
+func OpenGroup(ctx context.Context, path string) (*Group, error) {
+	store, err := OpenSharedBucket(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	g, err := store.OpenGroup(ctx, "")
+	// The group holds its own reference, as for NewReader.
+	store.Close()
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// OpenGroup opens the group stored under the given key prefix, checking that
+// it has a .zgroup file for zarr_format 2. The returned Group holds its own
+// reference to the bucket.
+func (s *SharedBucket) OpenGroup(ctx context.Context, path string) (*Group, error) {
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	g := &Group{store: s, ref: newBucketRef(s), prefix: keyPrefix(path)}
+	if err := g.checkFormat(ctx); err != nil {
+		g.ref.release()
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *Group) checkFormat(ctx context.Context) error {
+	data, err := g.store.bucket.ReadAll(ctx, g.prefix+".zgroup")
+	if err != nil {
+		return fmt.Errorf("failed to open .zgroup: %w", err)
+	}
+	var meta struct {
+		ZarrFormat int `json:"zarr_format"`
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return fmt.Errorf("failed to decode .zgroup: %w", err)
+	}
+	if meta.ZarrFormat != 2 {
+		return fmt.Errorf("unsupported zarr_format: %d, expected 2", meta.ZarrFormat)
+	}
+	return nil
+}
+
+// Path returns the group's key prefix within the bucket, without a trailing
+// slash; the root group has an empty path.
+func (g *Group) Path() string {
+	return strings.TrimSuffix(g.prefix, "/")
+}
+
+// ListArrays returns the names of the arrays directly inside the group, in
+// lexical order.
+func (g *Group) ListArrays(ctx context.Context) ([]string, error) {
+	return g.listChildren(ctx, ".zarray")
+}
+
+// ListGroups returns the names of the groups directly inside the group, in
+// lexical order. Deeper levels are reached by opening each with OpenGroup
+// and listing it in turn.
+func (g *Group) ListGroups(ctx context.Context) ([]string, error) {
+	return g.listChildren(ctx, ".zgroup")
+}
+
+// listChildren returns the names of the group's immediate children that hold
+// the given metadata file.
+func (g *Group) listChildren(ctx context.Context, file string) ([]string, error) {
+	iter := g.store.bucket.List(&blob.ListOptions{Prefix: g.prefix, Delimiter: "/"})
+	var names []string
+	for {
+		obj, err := iter.Next(ctx)
+		if err == io.EOF {
+			return names, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list group: %w", err)
+		}
+		if !obj.IsDir {
+			continue
+		}
+		ok, err := g.store.bucket.Exists(ctx, obj.Key+file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s%s: %w", obj.Key, file, err)
+		}
+		if ok {
+			names = append(names, strings.TrimSuffix(strings.TrimPrefix(obj.Key, g.prefix), "/"))
+		}
+	}
+}
+
+// OpenArray opens the array with the given name, or slash-separated path,
+// relative to the group. The returned Reader holds its own reference to the
+// bucket.
+func (g *Group) OpenArray(ctx context.Context, name string) (*Reader, error) {
+	return g.store.OpenArray(ctx, g.prefix+name)
+}
+
+// OpenGroup opens the sub-group with the given name, or slash-separated
+// path, relative to the group.
+func (g *Group) OpenGroup(ctx context.Context, name string) (*Group, error) {
+	return g.store.OpenGroup(ctx, g.prefix+name)
+}
+
+// Close releases the group's reference to the bucket. Arrays and groups
+// opened from it stay usable until they are closed.
+func (g *Group) Close() error {
+	return g.ref.release()
+}
@@ -0,0 +1,110 @@
+package zarr_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+// writeGroup writes a .zgroup file into dir.
+func writeGroup(t *testing.T, dir string) {
+	t.Helper()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".zgroup"), []byte(`{"zarr_format": 2}`), 0644); err != nil {
+		t.Fatalf("failed to write .zgroup: %v", err)
+	}
+}
+
+func TestGroup_TwoLevelHierarchy(t *testing.T) {
+	// root/
+	//   a       array
+	//   b       array
+	//   labels/ group
+	//     mask  array
+	//   stray/  neither array nor group
+	dir := t.TempDir()
+	writeGroup(t, dir)
+	writeFloat32Array(t, filepath.Join(dir, "a"), sequential4x4, map[string][]float32{
+		"0.0": {0, 1, 4, 5},
+	})
+	writeFloat32Array(t, filepath.Join(dir, "b"), sequential4x4, nil)
+	writeGroup(t, filepath.Join(dir, "labels"))
+	writeFloat32Array(t, filepath.Join(dir, "labels", "mask"), sequential4x4, nil)
+	if err := os.MkdirAll(filepath.Join(dir, "stray"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "stray", "notes.txt"), []byte("x"), 0644)
+	ctx := context.Background()
+
+	root, err := zarr.OpenGroup(ctx, "file:///"+filepath.ToSlash(dir))
+	if err != nil {
+		t.Fatalf("OpenGroup failed: %v", err)
+	}
+	defer root.Close()
+
+	arrays, err := root.ListArrays(ctx)
+	if err != nil {
+		t.Fatalf("ListArrays failed: %v", err)
+	}
+	if want := []string{"a", "b"}; !slices.Equal(arrays, want) {
+		t.Errorf("expected arrays %v, got %v", want, arrays)
+	}
+	groups, err := root.ListGroups(ctx)
+	if err != nil {
+		t.Fatalf("ListGroups failed: %v", err)
+	}
+	if want := []string{"labels"}; !slices.Equal(groups, want) {
+		t.Errorf("expected groups %v, got %v", want, groups)
+	}
+
+	a, err := root.OpenArray(ctx, "a")
+	if err != nil {
+		t.Fatalf("OpenArray failed: %v", err)
+	}
+	defer a.Close()
+	region, err := a.ReadRegion(ctx, []int{0, 0}, []int{2, 2})
+	if err != nil {
+		t.Fatalf("ReadRegion failed: %v", err)
+	}
+	if want := []float32{0, 1, 4, 5}; !slices.Equal(decodeFloat32(region), want) {
+		t.Errorf("expected %v, got %v", want, decodeFloat32(region))
+	}
+
+	labels, err := root.OpenGroup(ctx, "labels")
+	if err != nil {
+		t.Fatalf("OpenGroup(labels) failed: %v", err)
+	}
+	defer labels.Close()
+	if labels.Path() != "labels" {
+		t.Errorf("expected path labels, got %q", labels.Path())
+	}
+	arrays, err = labels.ListArrays(ctx)
+	if err != nil {
+		t.Fatalf("ListArrays failed: %v", err)
+	}
+	if want := []string{"mask"}; !slices.Equal(arrays, want) {
+		t.Errorf("expected arrays %v, got %v", want, arrays)
+	}
+	if groups, err := labels.ListGroups(ctx); err != nil || len(groups) != 0 {
+		t.Errorf("expected no nested groups, got %v, %v", groups, err)
+	}
+	mask, err := labels.OpenArray(ctx, "mask")
+	if err != nil {
+		t.Fatalf("OpenArray(mask) failed: %v", err)
+	}
+	mask.Close()
+
+	if _, err := root.OpenGroup(ctx, "a"); err == nil {
+		t.Error("expected opening an array as a group to fail")
+	}
+	if _, err := root.OpenGroup(ctx, "stray"); err == nil {
+		t.Error("expected opening a directory without .zgroup to fail")
+	}
+}
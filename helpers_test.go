@@ -0,0 +1,195 @@
+package zarr_test
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	_ "gocloud.dev/blob/fileblob"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+// writeArray writes a .zarray and raw chunk files into dir.
+func writeArray(t *testing.T, dir string, zarray string, chunks map[string][]byte) {
+	t.Helper()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".zarray"), []byte(zarray), 0644); err != nil {
+		t.Fatalf("failed to write .zarray: %v", err)
+	}
+	for name, data := range chunks {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directories for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("failed to write chunk %s: %v", name, err)
+		}
+	}
+}
+
+// writeFloat32Array writes a .zarray and little-endian float32 chunks into dir.
+func writeFloat32Array(t *testing.T, dir string, zarray string, chunks map[string][]float32) {
+	t.Helper()
+
+	raw := make(map[string][]byte, len(chunks))
+	for name, values := range chunks {
+		raw[name] = encodeLE(t, values)
+	}
+	writeArray(t, dir, zarray, raw)
+}
+
+// encodeLE encodes a slice of fixed-size values in little-endian order.
+func encodeLE(t *testing.T, values any) []byte {
+	t.Helper()
+
+	data, err := binary.Append(nil, binary.LittleEndian, values)
+	if err != nil {
+		t.Fatalf("failed to encode %T: %v", values, err)
+	}
+	return data
+}
+
+// decodeFloat32 decodes little-endian float32 values.
+func decodeFloat32(data []byte) []float32 {
+	out := make([]float32, len(data)/4)
+	for i := range out {
+		out[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+	return out
+}
+
+const sequential4x4 = `{
+	"zarr_format": 2,
+	"shape": [4, 4],
+	"chunks": [2, 2],
+	"dtype": "<f4",
+	"compressor": null,
+	"fill_value": 0.0,
+	"order": "C"
+}`
+
+// openSequential4x4 writes a 4x4 float32 array holding the values 0 to 15 in
+// 2x2 chunks and opens a Reader on it.
+func openSequential4x4(t *testing.T) *zarr.Reader {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, sequential4x4, map[string][]float32{
+		"0.0": {0, 1, 4, 5},
+		"0.1": {2, 3, 6, 7},
+		"1.0": {8, 9, 12, 13},
+		"1.1": {10, 11, 14, 15},
+	})
+	return openReader(t, dir)
+}
+
+// openReader opens a Reader on a local directory and closes it with the test.
+func openReader(t *testing.T, dir string) *zarr.Reader {
+	t.Helper()
+
+	reader, err := zarr.NewReader(context.Background(), "file:///"+filepath.ToSlash(dir))
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+	t.Cleanup(func() { reader.Close() })
+	return reader
+}
+
+// chunkFloat32 splits a C-order array into full-size C-order chunks keyed by
+// their dot-separated chunk coordinates. Edge chunks are zero-padded.
+func chunkFloat32(shape, chunks []int, values []float32) map[string][]float32 {
+	grid := zarr.GridShape(shape, chunks)
+	chunkSize := 1
+	for _, c := range chunks {
+		chunkSize *= c
+	}
+
+	out := map[string][]float32{}
+	coords := make([]int, len(grid))
+	for {
+		data := make([]float32, chunkSize)
+		rel := make([]int, len(chunks))
+		for n := 0; n < chunkSize; n++ {
+			// Decompose n into chunk-relative coordinates.
+			rem := n
+			for i := len(chunks) - 1; i >= 0; i-- {
+				rel[i] = rem % chunks[i]
+				rem /= chunks[i]
+			}
+			flat, inBounds := 0, true
+			for i := range shape {
+				g := coords[i]*chunks[i] + rel[i]
+				if g >= shape[i] {
+					inBounds = false
+					break
+				}
+				flat = flat*shape[i] + g
+			}
+			if inBounds {
+				data[n] = values[flat]
+			}
+		}
+		out[zarr.ChunkKey(coords, ".")] = data
+
+		// Advance to the next chunk in C order.
+		i := len(grid) - 1
+		for ; i >= 0; i-- {
+			coords[i]++
+			if coords[i] < grid[i] {
+				break
+			}
+			coords[i] = 0
+		}
+		if i < 0 {
+			return out
+		}
+	}
+}
+
+// openSequential writes a float32 array of the given shape and chunking
+// holding the values 0, 1, 2, ... in C order and opens a Reader on it.
+func openSequential(t *testing.T, shape, chunks []int) *zarr.Reader {
+	t.Helper()
+
+	size := 1
+	for _, s := range shape {
+		size *= s
+	}
+	values := make([]float32, size)
+	for i := range values {
+		values[i] = float32(i)
+	}
+
+	zarray := fmt.Sprintf(`{
+		"zarr_format": 2,
+		"shape": %s,
+		"chunks": %s,
+		"dtype": "<f4",
+		"compressor": null,
+		"fill_value": 0.0,
+		"order": "C"
+	}`, jsonInts(shape), jsonInts(chunks))
+
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, zarray, chunkFloat32(shape, chunks, values))
+	return openReader(t, dir)
+}
+
+// jsonInts formats a slice of ints as a JSON array.
+func jsonInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
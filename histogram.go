@@ -0,0 +1,162 @@
+package zarr
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Histogram counts the array's values in bins equal-width bins spanning
+// [min, max], streaming chunks rather than materializing the array, using
+// the reader's concurrency. Values equal to max fall in the last bin; values
+// outside the range, NaNs, infinities and elements equal to the fill value
+// are not counted, and missing chunks are skipped entirely. If min is not
+// less than max, the range is taken from the data in an extra pass over the
+// chunks.
+func (r *Reader) Histogram(ctx context.Context, bins int, min, max float64) ([]int64, error) {
+	if r.viewErr != nil {
+		return nil, r.viewErr
+	}
+	if bins <= 0 {
+		return nil, fmt.Errorf("histogram needs at least one bin, got %d", bins)
+	}
+
+	if !(min < max) {
+		min, max = math.Inf(1), math.Inf(-1)
+		err := r.forEachValue(ctx, func(v float64) {
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if math.IsInf(min, 1) {
+			// No values to count.
+			return make([]int64, bins), nil
+		}
+	}
+
+	counts := make([]int64, bins)
+	width := (max - min) / float64(bins)
+	err := r.forEachValue(ctx, func(v float64) {
+		if v < min || v > max {
+			return
+		}
+		bin := bins - 1
+		if width > 0 {
+			// A range too wide for float64 can make the quotient NaN or
+			// Inf, so clamp whatever the conversion yields.
+			bin = int((v - min) / width)
+			if bin < 0 {
+				bin = 0
+			} else if bin >= bins {
+				bin = bins - 1
+			}
+		}
+		counts[bin]++
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// forEachValue calls fn for every finite element of the array's stored
+// chunks that is not equal to the fill value. Chunks are fetched and decoded by up to r.concurrency workers, in
+// no particular order, but fn is never called concurrently. Missing chunks
+// and padding beyond the array edge in boundary chunks are skipped.
+func (r *Reader) forEachValue(ctx context.Context, fn func(v float64)) error {
+	if r.rawItemSize > 0 {
+		return fmt.Errorf("numeric operations are not supported on raw dtype views")
+	}
+	name, _, err := ParseDType(r.meta.DType)
+	if err != nil {
+		return fmt.Errorf("invalid dtype: %w", err)
+	}
+	typ, ok := goTypes[name]
+	if !ok {
+		return fmt.Errorf("no Go type for dtype %s", name)
+	}
+	fill, hasFill := r.storedFill()
+
+	grid := GridShape(r.meta.Shape, r.meta.Chunks)
+	last := make([]int, len(grid))
+	for i, n := range grid {
+		last[i] = n - 1
+	}
+	chunkStrides := r.chunkStrides()
+
+	var mu sync.Mutex
+	return r.visitChunks(ctx, make([]int, len(grid)), last, ChunkOrderC, func(ctx context.Context, coords []int) error {
+		data, found, err := r.fetchChunk(ctx, coords, readOptions{})
+		if err != nil || !found {
+			return err
+		}
+		values, err := decodeSlice(data, typ)
+		if err != nil {
+			return fmt.Errorf("chunk %s: %w", r.chunkKey(coords), err)
+		}
+
+		// Visit only the part of the chunk inside the array, collecting its
+		// values so that fn runs under the lock once per chunk.
+		batch := make([]float64, 0, values.Len())
+		extent := make([]int, len(coords))
+		for i, c := range coords {
+			extent[i] = min(r.meta.Chunks[i], r.meta.Shape[i]-c*r.meta.Chunks[i])
+		}
+		var visit func(dim, offset int) error
+		visit = func(dim, offset int) error {
+			if dim == len(extent) {
+				if offset >= values.Len() {
+					return fmt.Errorf("chunk %s: %w", r.chunkKey(coords), ErrChunkCorrupt)
+				}
+				v, err := elementFloat(values.Index(offset))
+				if err != nil {
+					return err
+				}
+				if !math.IsNaN(v) && !math.IsInf(v, 0) && !(hasFill && v == fill) {
+					batch = append(batch, v)
+				}
+				return nil
+			}
+			for i := 0; i < extent[dim]; i++ {
+				if err := visit(dim+1, offset+i*chunkStrides[dim]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := visit(0, 0); err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, v := range batch {
+			fn(v)
+		}
+		return nil
+	})
+}
+
+// storedFill returns the array's fill value as a float64, if it has a
+// numeric one, rounded to the precision of the dtype so that it compares
+// equal to stored elements widened to float64.
+func (r *Reader) storedFill() (float64, bool) {
+	fill, ok := fillFloat64(r.meta.FillValue)
+	if !ok {
+		return 0, false
+	}
+	return storedFloat(r.meta.DType, fill), true
+}
+
+// storedFloat rounds f to the precision of a float dtype, so that it
+// compares equal to elements of that dtype widened to float64. Other dtypes
+// are returned unchanged.
+func storedFloat(dtype string, f float64) float64 {
+	if name, _, err := ParseDType(dtype); err == nil && name == "float32" {
+		return float64(float32(f))
+	}
+	return f
+}
@@ -0,0 +1,117 @@
+package zarr_test
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_Histogram(t *testing.T) {
+	// A 3x3 array in 2x2 chunks, so edge chunks carry padding that must not
+	// be counted. Chunk 1.1 is missing and the fill value is -1, which is
+	// not counted where it is stored either.
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [3, 3],
+			"chunks": [2, 2],
+			"dtype": "<f8",
+			"compressor": null,
+			"fill_value": -1,
+			"order": "C"
+		}`),
+		"0.0": encodeLE(t, []float64{0, 1, 2, -1}),
+		"0.1": encodeLE(t, []float64{3, 99, 9, 99}),
+		"1.0": encodeLE(t, []float64{math.NaN(), 10, 99, 99}),
+	})
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	// Counted values: 0, 1, 2, 3, 9, 10.
+	got, err := reader.Histogram(ctx, 5, 0, 10)
+	if err != nil {
+		t.Fatalf("Histogram failed: %v", err)
+	}
+	if want := []int64{2, 2, 0, 0, 2}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// With no range given it is taken from the data.
+	got, err = reader.Histogram(ctx, 2, 0, 0)
+	if err != nil {
+		t.Fatalf("Histogram with automatic range failed: %v", err)
+	}
+	if want := []int64{4, 2}; !slices.Equal(got, want) {
+		t.Errorf("expected %v with automatic range, got %v", want, got)
+	}
+
+	// Values outside an explicit range are ignored.
+	got, err = reader.Histogram(ctx, 1, 1, 3)
+	if err != nil {
+		t.Fatalf("Histogram failed: %v", err)
+	}
+	if want := []int64{3}; !slices.Equal(got, want) {
+		t.Errorf("expected %v for range [1, 3], got %v", want, got)
+	}
+
+	// Stored fill values are skipped like the missing chunk.
+	got, err = reader.Histogram(ctx, 1, -1, 0)
+	if err != nil {
+		t.Fatalf("Histogram failed: %v", err)
+	}
+	if want := []int64{1}; !slices.Equal(got, want) {
+		t.Errorf("expected %v for range [-1, 0], got %v", want, got)
+	}
+
+	// Concurrent chunk reads give the same counts.
+	got, err = reader.WithOptions(zarr.ReaderOptions{Concurrency: 4}).Histogram(ctx, 5, 0, 10)
+	if err != nil {
+		t.Fatalf("concurrent Histogram failed: %v", err)
+	}
+	if want := []int64{2, 2, 0, 0, 2}; !slices.Equal(got, want) {
+		t.Errorf("expected %v with concurrency, got %v", want, got)
+	}
+
+	if _, err := reader.Histogram(ctx, 0, 0, 1); err == nil {
+		t.Error("expected an error for zero bins")
+	}
+}
+
+func TestReader_HistogramNonFinite(t *testing.T) {
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [5],
+			"chunks": [5],
+			"dtype": "<f4",
+			"compressor": null,
+			"fill_value": 0.1,
+			"order": "C"
+		}`),
+		"0": encodeLE(t, []float32{1, 2, float32(math.Inf(1)), float32(math.Inf(-1)), 0.1}),
+	})
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	// Infinities are skipped when taking the range, and the float32 fill
+	// value 0.1 is recognised despite widening to a different float64.
+	got, err := reader.Histogram(ctx, 4, 0, 0)
+	if err != nil {
+		t.Fatalf("Histogram failed: %v", err)
+	}
+	if want := []int64{1, 0, 0, 1}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// A range too wide for its width to be finite still bins every value.
+	got, err = reader.Histogram(ctx, 2, -math.MaxFloat64, math.MaxFloat64)
+	if err != nil {
+		t.Fatalf("Histogram failed: %v", err)
+	}
+	if total := got[0] + got[1]; total != 2 {
+		t.Errorf("expected 2 values counted over the full float64 range, got %v", got)
+	}
+}
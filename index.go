@@ -0,0 +1,261 @@
+package zarr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"gocloud.dev/blob"
+)
+
+// chunkIndex records which chunks of the grid are present in the store, one
+// bit per chunk in C order.
+type chunkIndex struct {
+	grid []int
+	bits []uint64
+}
+
+func (idx *chunkIndex) linear(coords []int) (int, bool) {
+	if len(coords) != len(idx.grid) {
+		return 0, false
+	}
+	n := 0
+	for i, c := range coords {
+		if c < 0 || c >= idx.grid[i] {
+			return 0, false
+		}
+		n = n*idx.grid[i] + c
+	}
+	return n, true
+}
+
+func (idx *chunkIndex) set(coords []int) {
+	if n, ok := idx.linear(coords); ok {
+		idx.bits[n/64] |= 1 << (n % 64)
+	}
+}
+
+func (idx *chunkIndex) has(coords []int) bool {
+	n, ok := idx.linear(coords)
+	return ok && idx.bits[n/64]&(1<<(n%64)) != 0
+}
+
+// PrimeChunkIndex lists the array's chunks once and caches which ones exist,
+// so that later reads on this reader, and on views derived from it afterwards,
+// fill absent chunks without asking the bucket for them. Chunks written after
+// priming are not seen until PrimeChunkIndex is called again. It must not be
+// called concurrently with reads on the same reader.
+func (r *Reader) PrimeChunkIndex(ctx context.Context) error {
+	grid := GridShape(r.meta.Shape, r.meta.Chunks)
+	total := 1
+	for _, n := range grid {
+		total *= n
+	}
+	idx := &chunkIndex{grid: grid, bits: make([]uint64, (total+63)/64)}
+
+	err := r.listChunks(ctx, func(coords []int, _ *blob.ListObject) {
+		idx.set(coords)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.chunkIndex = idx
+	return nil
+}
+
+// listChunks calls fn for every stored object of the array whose key is a
+// chunk key of the array's rank, in listing order.
+func (r *Reader) listChunks(ctx context.Context, fn func(coords []int, obj *blob.ListObject)) error {
+	opts := &blob.ListOptions{Prefix: r.prefix}
+	if !r.encoding.nested() {
+		// Skip the contents of sub-groups and nested arrays.
+		opts.Delimiter = "/"
+	}
+	iter := r.store.bucket.List(opts)
+	for {
+		obj, err := iter.Next(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list chunks: %w", err)
+		}
+		if obj.IsDir {
+			continue
+		}
+		if coords, ok := r.parseChunkKey(strings.TrimPrefix(obj.Key, r.prefix)); ok {
+			fn(coords, obj)
+		}
+	}
+}
+
+// DetectDuplicateChunks lists the array and returns, in C order, the
+// coordinates of chunks stored under more than one dimension separator, such
+// as both "0.0" and "0/0" left behind by a migration. Reads always use the
+// key of the declared separator and ignore the other copies; a reader with a
+// logger set by WithLogger also reports each duplicate as a warning.
+func (r *Reader) DetectDuplicateChunks(ctx context.Context) ([][]int, error) {
+	encodings := []ChunkEncoding{r.encoding}
+	for _, sep := range []string{".", "/"} {
+		if sep != r.encoding.separator() {
+			alt := r.encoding
+			alt.Separator = sep
+			encodings = append(encodings, alt)
+		}
+	}
+
+	rank := len(r.meta.Shape)
+	seen := make(map[string][]string)
+	var dups [][]int
+	iter := r.store.bucket.List(&blob.ListOptions{Prefix: r.prefix})
+	for {
+		obj, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list chunks: %w", err)
+		}
+		key := strings.TrimPrefix(obj.Key, r.prefix)
+		for _, enc := range encodings {
+			coords, ok := enc.Decode(key)
+			if !ok || len(coords) != rank {
+				continue
+			}
+			id := fmt.Sprint(coords)
+			if len(seen[id]) == 1 {
+				dups = append(dups, coords)
+			}
+			seen[id] = append(seen[id], key)
+			break
+		}
+	}
+
+	slices.SortFunc(dups, slices.Compare)
+	for _, coords := range dups {
+		if r.logger != nil {
+			r.logger.LogAttrs(ctx, slog.LevelWarn, "duplicate chunk",
+				slog.Any("coords", coords),
+				slog.String("using", r.chunkKey(coords)),
+				slog.Any("keys", seen[fmt.Sprint(coords)]))
+		}
+	}
+	return dups, nil
+}
+
+// ChunkGrid returns the number of chunks along each stored dimension of the
+// array.
+func (r *Reader) ChunkGrid() []int {
+	return GridShape(r.meta.Shape, r.meta.Chunks)
+}
+
+// EachChunk calls fn with the coordinates of every chunk in the grid, in C
+// order, whether or not the chunk is stored; see ChunkExists. Iteration
+// stops at the first error fn returns, or once ctx is done. fn may keep
+// coords.
+func (r *Reader) EachChunk(ctx context.Context, fn func(coords []int) error) error {
+	grid := r.ChunkGrid()
+	last := make([]int, len(grid))
+	for i, n := range grid {
+		if n == 0 {
+			return nil
+		}
+		last[i] = n - 1
+	}
+	return forEachChunk(make([]int, len(grid)), last, ChunkOrderC, func(coords []int) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fn(slices.Clone(coords))
+	})
+}
+
+// ChunkExists reports whether the chunk at coords is stored. A chunk index
+// primed with PrimeChunkIndex answers without a request to the bucket.
+func (r *Reader) ChunkExists(ctx context.Context, coords []int) (bool, error) {
+	grid := r.ChunkGrid()
+	if len(coords) != len(grid) {
+		return false, fmt.Errorf("chunk coordinates %v do not match array rank %d", coords, len(grid))
+	}
+	for i, c := range coords {
+		if c < 0 || c >= grid[i] {
+			return false, fmt.Errorf("chunk coordinates %v out of bounds for grid %v", coords, grid)
+		}
+	}
+	if r.chunkIndex != nil {
+		return r.chunkIndex.has(coords), nil
+	}
+	key := r.chunkKey(coords)
+	ok, err := r.store.bucket.Exists(ctx, r.key(key))
+	if err != nil {
+		return false, fmt.Errorf("failed to check chunk %s: %w", key, err)
+	}
+	return ok, nil
+}
+
+// ChunkStats counts the chunks of the grid that are stored, so that
+// incomplete uploads can be told apart from arrays that are sparse on
+// purpose. Each chunk is checked with ChunkExists, without downloading it,
+// by up to ReaderOptions.Concurrency requests at a time.
+func (r *Reader) ChunkStats(ctx context.Context) (present, total int, err error) {
+	grid := r.ChunkGrid()
+	total = 1
+	last := make([]int, len(grid))
+	for i, n := range grid {
+		total *= n
+		last[i] = n - 1
+	}
+	if total == 0 {
+		return 0, 0, nil
+	}
+	if len(grid) == 0 {
+		ok, err := r.ChunkExists(ctx, []int{})
+		if err != nil || !ok {
+			return 0, total, err
+		}
+		return 1, total, nil
+	}
+
+	var count atomic.Int64
+	err = r.visitChunks(ctx, make([]int, len(grid)), last, ChunkOrderC, func(ctx context.Context, coords []int) error {
+		ok, err := r.ChunkExists(ctx, coords)
+		if ok {
+			count.Add(1)
+		}
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(count.Load()), total, nil
+}
+
+// knownAbsent reports whether a primed chunk index says the chunk at coords
+// does not exist.
+func (r *Reader) knownAbsent(coords []int) bool {
+	return r.chunkIndex != nil && !r.chunkIndex.has(coords)
+}
+
+// chunkKey returns the key, relative to the array, of the chunk at coords.
+func (r *Reader) chunkKey(coords []int) string {
+	return r.encoding.Encode(coords)
+}
+
+// parseChunkKey is the inverse of chunkKey. It rejects metadata keys and
+// anything that is not a chunk of the array's rank.
+func (r *Reader) parseChunkKey(key string) ([]int, bool) {
+	rank := len(r.meta.Shape)
+	if rank == 0 {
+		return []int{}, key == r.encoding.Encode(nil)
+	}
+	coords, ok := r.encoding.Decode(key)
+	if !ok || len(coords) != rank {
+		return nil, false
+	}
+	return coords, true
+}
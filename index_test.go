@@ -0,0 +1,202 @@
+package zarr_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_PrimeChunkIndex(t *testing.T) {
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [4, 4],
+			"chunks": [2, 2],
+			"dtype": "<f4",
+			"compressor": null,
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+		"0.0": encodeLE(t, []float32{1, 2, 3, 4}),
+		"1.1": encodeLE(t, []float32{5, 6, 7, 8}),
+	})
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	if err := reader.PrimeChunkIndex(ctx); err != nil {
+		t.Fatalf("PrimeChunkIndex failed: %v", err)
+	}
+	fb.resetReads()
+
+	data, err := reader.ReadChunk(ctx, []int{0, 1})
+	if err != nil {
+		t.Fatalf("ReadChunk on a missing chunk failed: %v", err)
+	}
+	if got := decodeFloat32(data); len(got) != 4 || got[0] != 0 || got[3] != 0 {
+		t.Errorf("expected a zero-filled chunk, got %v", got)
+	}
+	if n := fb.readCount(); n != 0 {
+		t.Errorf("expected no bucket reads for a known-absent chunk, got %d", n)
+	}
+
+	// A region touching only absent chunks, including through the range
+	// read path, must not reach the bucket either.
+	if _, err := reader.ReadRegion(ctx, []int{2, 0}, []int{1, 2}); err != nil {
+		t.Fatalf("ReadRegion failed: %v", err)
+	}
+	if n := fb.readCount(); n != 0 {
+		t.Errorf("expected no bucket reads for a region of absent chunks, got %d", n)
+	}
+
+	_, err = reader.ReadChunk(ctx, []int{1, 0}, zarr.WithNotFoundPolicy(zarr.NotFoundError))
+	if !errors.Is(err, zarr.ErrChunkNotFound) {
+		t.Errorf("expected ErrChunkNotFound under NotFoundError, got %v", err)
+	}
+
+	data, err = reader.ReadChunk(ctx, []int{1, 1})
+	if err != nil {
+		t.Fatalf("ReadChunk on a present chunk failed: %v", err)
+	}
+	if got := decodeFloat32(data); got[0] != 5 || got[3] != 8 {
+		t.Errorf("unexpected data for chunk 1.1: %v", got)
+	}
+	if n := len(fb.readsOf("1.1")); n != 1 {
+		t.Errorf("expected one read of chunk 1.1, got %d", n)
+	}
+}
+
+func TestReader_DetectDuplicateChunks(t *testing.T) {
+	for _, tc := range []struct {
+		separator string
+		want      float32
+	}{
+		{".", 1},
+		{"/", 2},
+	} {
+		t.Run(tc.separator, func(t *testing.T) {
+			fb := newFakeBucket(map[string][]byte{
+				".zarray": []byte(`{
+					"zarr_format": 2,
+					"shape": [4, 4],
+					"chunks": [2, 2],
+					"dtype": "<f4",
+					"compressor": null,
+					"fill_value": 0.0,
+					"order": "C",
+					"dimension_separator": "` + tc.separator + `"
+				}`),
+				"0.0": encodeLE(t, []float32{1, 1, 1, 1}),
+				"0/0": encodeLE(t, []float32{2, 2, 2, 2}),
+				"1.1": encodeLE(t, []float32{3, 3, 3, 3}),
+				"1/0": encodeLE(t, []float32{4, 4, 4, 4}),
+			})
+			reader := openFake(t, fb)
+			ctx := context.Background()
+
+			data, err := reader.ReadChunk(ctx, []int{0, 0})
+			if err != nil {
+				t.Fatalf("ReadChunk failed: %v", err)
+			}
+			if got := decodeFloat32(data); got[0] != tc.want {
+				t.Errorf("expected the chunk under separator %q (%v), got %v", tc.separator, tc.want, got)
+			}
+
+			dups, err := reader.DetectDuplicateChunks(ctx)
+			if err != nil {
+				t.Fatalf("DetectDuplicateChunks failed: %v", err)
+			}
+			if len(dups) != 1 || len(dups[0]) != 2 || dups[0][0] != 0 || dups[0][1] != 0 {
+				t.Errorf("expected only chunk [0 0] to be reported, got %v", dups)
+			}
+		})
+	}
+}
+
+func TestReader_ChunkGridAndEachChunk(t *testing.T) {
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, sequential4x4, map[string][]float32{
+		"0.0": {0, 1, 4, 5},
+		"1.1": {10, 11, 14, 15},
+	})
+	reader := openReader(t, dir)
+	ctx := context.Background()
+
+	if grid := reader.ChunkGrid(); !slices.Equal(grid, []int{2, 2}) {
+		t.Errorf("expected grid [2 2], got %v", grid)
+	}
+
+	var all, stored [][]int
+	err := reader.EachChunk(ctx, func(coords []int) error {
+		all = append(all, coords)
+		ok, err := reader.ChunkExists(ctx, coords)
+		if err != nil {
+			return err
+		}
+		if ok {
+			stored = append(stored, coords)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachChunk failed: %v", err)
+	}
+	if want := [][]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}}; !slices.EqualFunc(all, want, slices.Equal) {
+		t.Errorf("expected chunks %v, got %v", want, all)
+	}
+	if want := [][]int{{0, 0}, {1, 1}}; !slices.EqualFunc(stored, want, slices.Equal) {
+		t.Errorf("expected stored chunks %v, got %v", want, stored)
+	}
+
+	stop := errors.New("stop")
+	visited := 0
+	err = reader.EachChunk(ctx, func([]int) error {
+		visited++
+		return stop
+	})
+	if !errors.Is(err, stop) || visited != 1 {
+		t.Errorf("expected EachChunk to stop at the first error, got %v after %d chunks", err, visited)
+	}
+
+	if _, err := reader.ChunkExists(ctx, []int{2, 0}); err == nil {
+		t.Error("expected out-of-grid coordinates to be rejected")
+	}
+	if _, err := reader.ChunkExists(ctx, []int{0}); err == nil {
+		t.Error("expected coordinates of the wrong rank to be rejected")
+	}
+}
+
+func TestReader_ChunkStats(t *testing.T) {
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(sequential4x4),
+		"0.0":     encodeLE(t, []float32{0, 1, 4, 5}),
+		"0.1":     encodeLE(t, []float32{2, 3, 6, 7}),
+		"1.1":     encodeLE(t, []float32{10, 11, 14, 15}),
+	})
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	for name, r := range map[string]*zarr.Reader{
+		"sequential": reader,
+		"concurrent": reader.WithOptions(zarr.ReaderOptions{Concurrency: 4}),
+	} {
+		present, total, err := r.ChunkStats(ctx)
+		if err != nil {
+			t.Fatalf("%s: ChunkStats failed: %v", name, err)
+		}
+		if present != 3 || total != 4 {
+			t.Errorf("%s: expected 3 of 4 chunks present, got %d of %d", name, present, total)
+		}
+	}
+	if n := fb.readCount(); n != 0 {
+		t.Errorf("expected ChunkStats to download no chunks, got %d reads", n)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, _, err := reader.ChunkStats(cancelled); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package zarr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// lazyCacheChunks is how many decoded chunks a LazyArray keeps.
+const lazyCacheChunks = 16
+
+// LazyArray gives random access to single elements without reading the
+// whole array. Each chunk is fetched when one of its elements is accessed
+// and kept, decoded, in a private LRU cache of the most recently used
+// chunks. Fetches go through the reader as usual, including its WithCache
+// cache, which only ever holds chunks as stored.
+type LazyArray struct {
+	ctx    context.Context
+	r      *Reader
+	w      *Writer
+	chunks *LRUCache
+
+	// mu serializes the read-modify-write of Set.
+	mu sync.Mutex
+}
+
+// Lazy returns a LazyArray over the reader, including any view transform.
+// The context is used for the chunk reads made by At.
+func (r *Reader) Lazy(ctx context.Context) *LazyArray {
+	return &LazyArray{ctx: ctx, r: r, chunks: NewLRUCache(lazyCacheChunks)}
+}
+
+// Lazy returns a LazyArray over the array being written, whose Set stores
+// single elements. The context is used for the chunk reads and writes made
+// by At and Set.
+func (w *Writer) Lazy(ctx context.Context) *LazyArray {
+	return &LazyArray{ctx: ctx, r: w.reader, w: w, chunks: NewLRUCache(lazyCacheChunks)}
+}
+
+// At returns the element at coords, in the reader's view coordinates,
+// converted to float64 and passed through any WithElementTransform function.
+// Booleans read as 0 or 1; complex and raw dtypes are not supported.
+func (a *LazyArray) At(coords ...int) (float64, error) {
+	typ, chunkCoords, offset, err := a.locate(coords)
+	if err != nil {
+		return 0, err
+	}
+	chunk, err := a.chunk(chunkCoords)
+	if err != nil {
+		return 0, err
+	}
+	itemSize := int(typ.Size())
+	if offset+itemSize > len(chunk) {
+		return 0, fmt.Errorf("chunk %s: %w", a.r.chunkKey(chunkCoords), ErrChunkCorrupt)
+	}
+
+	elem, err := decodeSlice(chunk[offset:offset+itemSize], typ)
+	if err != nil {
+		return 0, err
+	}
+	v, err := elementFloat(elem.Index(0))
+	if err != nil {
+		return 0, err
+	}
+	return a.r.transformed(v), nil
+}
+
+// Set stores v as the element at coords of a LazyArray returned by
+// Writer.Lazy. v must be representable in the array's dtype: integral and
+// in range for integer dtypes, 0 or 1 for booleans. The owning chunk is
+// read, updated and written back at once, so a Set is visible to readers as
+// soon as it returns.
+func (a *LazyArray) Set(v float64, coords ...int) error {
+	if a.w == nil {
+		return fmt.Errorf("Set needs a LazyArray from Writer.Lazy")
+	}
+	_, chunkCoords, offset, err := a.locate(coords)
+	if err != nil {
+		return err
+	}
+	dtype := a.r.meta.DType
+	if dtype[1] == 'c' {
+		return fmt.Errorf("Set is not supported for complex dtype %s", dtype)
+	}
+	elem, err := encodeFill(dtype, v)
+	if err != nil {
+		return fmt.Errorf("cannot store %v in a %s array: %w", v, dtype, err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	chunk, err := a.chunk(chunkCoords)
+	if err != nil {
+		return err
+	}
+	// Cached chunks are never modified in place.
+	chunk = bytes.Clone(chunk)
+	item := chunk[offset : offset+a.w.itemSize]
+	clear(item)
+	copy(item, elem)
+	if err := a.w.WriteChunk(a.ctx, chunkCoords, chunk); err != nil {
+		return err
+	}
+	a.chunks.Add(a.r.chunkKey(chunkCoords), chunk)
+	return nil
+}
+
+// locate returns the element type of the array and the chunk and byte
+// offset within it holding the element at coords, in view coordinates.
+func (a *LazyArray) locate(coords []int) (typ reflect.Type, chunkCoords []int, offset int, err error) {
+	r := a.r
+	if r.viewErr != nil {
+		return nil, nil, 0, r.viewErr
+	}
+	if r.rawItemSize > 0 {
+		return nil, nil, 0, fmt.Errorf("element access is not supported on raw dtype views")
+	}
+	name, itemSize, err := ParseDType(r.meta.DType)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("invalid dtype: %w", err)
+	}
+	typ, ok := goTypes[name]
+	if !ok {
+		return nil, nil, 0, fmt.Errorf("no Go type for dtype %s", name)
+	}
+
+	shape := r.Shape()
+	if len(coords) != len(shape) {
+		return nil, nil, 0, fmt.Errorf("expected %d coordinates, got %d", len(shape), len(coords))
+	}
+	chunkCoords = make([]int, len(coords))
+	inChunk := make([]int, len(coords))
+	for j, c := range coords {
+		if c < 0 || c >= shape[j] {
+			return nil, nil, 0, fmt.Errorf("coordinate %d out of bounds at dimension %d", c, j)
+		}
+		i := r.storageAxis(j)
+		if r.isFlipped(i) {
+			c = r.meta.Shape[i] - 1 - c
+		}
+		chunkCoords[i] = c / r.meta.Chunks[i]
+		inChunk[i] = c % r.meta.Chunks[i]
+	}
+	for i, s := range r.chunkStrides() {
+		offset += inChunk[i] * s
+	}
+	return typ, chunkCoords, offset * itemSize, nil
+}
+
+// chunk returns the decoded chunk at coords, fetching it unless it is
+// cached. Missing chunks are cached too, as chunks of the fill value.
+func (a *LazyArray) chunk(coords []int) ([]byte, error) {
+	key := a.r.chunkKey(coords)
+	if data, ok := a.chunks.Get(key); ok {
+		return data, nil
+	}
+	data, err := a.r.readChunk(a.ctx, coords, readOptions{})
+	if err != nil {
+		return nil, err
+	}
+	a.chunks.Add(key, data)
+	return data, nil
+}
+
+// elementFloat converts a decoded numeric or boolean element to float64.
+func elementFloat(v reflect.Value) (float64, error) {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	default:
+		return 0, fmt.Errorf("cannot convert %s element to float64", v.Type())
+	}
+}
@@ -0,0 +1,232 @@
+package zarr_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"gocloud.dev/blob"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestLazyArray_At(t *testing.T) {
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [4, 4],
+			"chunks": [2, 2],
+			"dtype": "<f4",
+			"compressor": null,
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+		"0.0": encodeLE(t, []float32{0, 1, 4, 5}),
+		"0.1": encodeLE(t, []float32{2, 3, 6, 7}),
+		"1.0": encodeLE(t, []float32{8, 9, 12, 13}),
+		"1.1": encodeLE(t, []float32{10, 11, 14, 15}),
+	})
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	full, err := reader.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+	want := decodeFloat32(full)
+	fb.resetReads()
+
+	lazy := reader.Lazy(ctx)
+	for _, p := range [][2]int{{0, 0}, {3, 3}, {1, 2}, {2, 1}, {0, 1}, {3, 0}} {
+		got, err := lazy.At(p[0], p[1])
+		if err != nil {
+			t.Fatalf("At(%d, %d) failed: %v", p[0], p[1], err)
+		}
+		if exp := float64(want[p[0]*4+p[1]]); got != exp {
+			t.Errorf("At(%d, %d): expected %v, got %v", p[0], p[1], exp, got)
+		}
+	}
+
+	// Points in the same chunk share one fetch.
+	if n := len(fb.readsOf("0.0")); n != 1 {
+		t.Errorf("expected chunk 0.0 to be fetched once, got %d", n)
+	}
+
+	if _, err := lazy.At(4, 0); err == nil {
+		t.Error("expected an error for out-of-bounds coordinates")
+	}
+	if _, err := lazy.At(1); err == nil {
+		t.Error("expected an error for the wrong number of coordinates")
+	}
+}
+
+func TestLazyArray_AtTransposed(t *testing.T) {
+	reader := openSequential4x4(t)
+	lazy := reader.Transpose([]int{1, 0}).Flip([]int{0}).Lazy(context.Background())
+
+	// View (i, j) is stored element (j, 3-i), holding 4*j + 3 - i.
+	got, err := lazy.At(0, 2)
+	if err != nil {
+		t.Fatalf("At failed: %v", err)
+	}
+	if got != 11 {
+		t.Errorf("expected 11, got %v", got)
+	}
+}
+
+func TestLazyArray_BoundedCache(t *testing.T) {
+	// 40 one-element chunks, more than a LazyArray keeps on its own.
+	files := map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [40],
+			"chunks": [1],
+			"dtype": "<f4",
+			"compressor": null,
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+	}
+	for i := range 40 {
+		files[strconv.Itoa(i)] = encodeLE(t, []float32{float32(i)})
+	}
+	fb := newFakeBucket(files)
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	visitAll := func(lazy *zarr.LazyArray) {
+		t.Helper()
+		for i := range 40 {
+			got, err := lazy.At(i)
+			if err != nil {
+				t.Fatalf("At(%d) failed: %v", i, err)
+			}
+			if got != float64(i) {
+				t.Errorf("At(%d): expected %d, got %v", i, i, got)
+			}
+		}
+	}
+
+	// Without a reader cache, early chunks are evicted and fetched again.
+	lazy := reader.Lazy(ctx)
+	visitAll(lazy)
+	visitAll(lazy)
+	if n := len(fb.readsOf("0")); n != 2 {
+		t.Errorf("expected chunk 0 to be evicted and fetched twice, got %d fetches", n)
+	}
+
+	// With one, the LazyArray shares it with the reader's other reads.
+	fb.resetReads()
+	cache := zarr.NewLRUCache(64)
+	cached := reader.WithCache(cache)
+	if _, err := cached.ReadFull(ctx); err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+	visitAll(cached.Lazy(ctx))
+	if n := len(fb.readsOf("0")); n != 1 {
+		t.Errorf("expected the lazy reads to hit the reader's cache, got %d fetches of chunk 0", n)
+	}
+	if n := cache.Len(); n != 40 {
+		t.Errorf("expected 40 cached chunks, got %d", n)
+	}
+}
+
+func TestLazyArray_SharedCacheBigEndian(t *testing.T) {
+	// Chunk 1 is missing; big-endian chunks are swapped on every read, so
+	// the reader's cache must only ever see them as stored.
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [3],
+			"chunks": [2],
+			"dtype": ">f4",
+			"compressor": null,
+			"fill_value": -1.0,
+			"order": "C"
+		}`),
+		"0": encodeBE(t, []float32{1.5, 2.5}),
+	})
+	reader := openFake(t, fb).WithCache(zarr.NewLRUCache(8))
+	ctx := context.Background()
+	want := []float32{1.5, 2.5, -1}
+
+	readFull := func() {
+		t.Helper()
+		data, err := reader.ReadFull(ctx)
+		if err != nil {
+			t.Fatalf("ReadFull failed: %v", err)
+		}
+		if got := decodeFloat32(data); !slices.Equal(got, want) {
+			t.Errorf("ReadFull: expected %v, got %v", want, got)
+		}
+	}
+	at := func(lazy *zarr.LazyArray) {
+		t.Helper()
+		for i, w := range want {
+			got, err := lazy.At(i)
+			if err != nil {
+				t.Fatalf("At(%d) failed: %v", i, err)
+			}
+			if got != float64(w) {
+				t.Errorf("At(%d): expected %v, got %v", i, w, got)
+			}
+		}
+	}
+
+	readFull()
+	at(reader.Lazy(ctx))
+	readFull()
+	at(reader.Lazy(ctx))
+	readFull()
+
+	// The fill chunk the LazyArray synthesized for the missing chunk does not
+	// leak into the reader's cache.
+	_, err := reader.ReadChunk(ctx, []int{1}, zarr.WithNotFoundPolicy(zarr.NotFoundError))
+	if !errors.Is(err, zarr.ErrChunkNotFound) {
+		t.Errorf("expected ErrChunkNotFound for the missing chunk, got %v", err)
+	}
+}
+
+func TestLazyArray_Set(t *testing.T) {
+	fb := newFakeBucket(nil)
+	store := zarr.NewSharedBucket(blob.NewBucket(fb))
+	defer store.Close()
+	ctx := context.Background()
+	w, err := store.CreateArray(ctx, "a", &zarr.Metadata{
+		Shape: []int{3, 3}, Chunks: []int{2, 2}, DType: ">i2", FillValue: -1,
+	})
+	if err != nil {
+		t.Fatalf("CreateArray failed: %v", err)
+	}
+	defer w.Close()
+
+	lazy := w.Lazy(ctx)
+	for _, p := range [][3]int{{0, 0, 7}, {2, 2, -300}, {0, 1, 8}} {
+		if err := lazy.Set(float64(p[2]), p[0], p[1]); err != nil {
+			t.Fatalf("Set(%d, %d) failed: %v", p[0], p[1], err)
+		}
+	}
+	if got, err := lazy.At(0, 1); err != nil || got != 8 {
+		t.Errorf("At(0, 1): expected 8, got %v, %v", got, err)
+	}
+
+	reader := openFakeArray(t, fb, "a")
+	got, err := zarr.ReadFullAs[int16](ctx, reader)
+	if err != nil {
+		t.Fatalf("ReadFullAs failed: %v", err)
+	}
+	if want := []int16{7, 8, -1, -1, -1, -1, -1, -1, -300}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	for _, v := range []float64{0.5, 40000} {
+		if err := lazy.Set(v, 1, 1); err == nil {
+			t.Errorf("expected Set(%v) to be rejected for <i2", v)
+		}
+	}
+	if err := openSequential4x4(t).Lazy(ctx).Set(1, 0, 0); err == nil {
+		t.Error("expected Set on a reader-backed LazyArray to fail")
+	}
+}
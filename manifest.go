@@ -0,0 +1,53 @@
+package zarr
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"gocloud.dev/blob"
+)
+
+// ChunkEntry describes one stored chunk of an array.
+type ChunkEntry struct {
+	Coords []int
+	// Key is the chunk key relative to the array, e.g. "1.2".
+	Key string
+	// Size is the stored, possibly compressed, size in bytes.
+	Size int64
+	// MD5 is the content hash reported by the bucket, or nil if the
+	// provider does not supply one in listings.
+	MD5 []byte
+}
+
+// ChunkManifest lists the chunks present in the store, in C order of their
+// coordinates, using a single bucket listing and without downloading any
+// chunk data. It is meant for building catalogs or reference files of an
+// existing array.
+func (r *Reader) ChunkManifest(ctx context.Context) ([]ChunkEntry, error) {
+	grid := GridShape(r.meta.Shape, r.meta.Chunks)
+
+	var entries []ChunkEntry
+	err := r.listChunks(ctx, func(coords []int, obj *blob.ListObject) {
+		for i, c := range coords {
+			if c >= grid[i] {
+				// Stale chunk outside the current shape.
+				return
+			}
+		}
+		entries = append(entries, ChunkEntry{
+			Coords: coords,
+			Key:    strings.TrimPrefix(obj.Key, r.prefix),
+			Size:   obj.Size,
+			MD5:    obj.MD5,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(entries, func(a, b ChunkEntry) int {
+		return slices.Compare(a.Coords, b.Coords)
+	})
+	return entries, nil
+}
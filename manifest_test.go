@@ -0,0 +1,49 @@
+package zarr_test
+
+import (
+	"context"
+	"crypto/md5"
+	"reflect"
+	"testing"
+)
+
+func TestReader_ChunkManifest(t *testing.T) {
+	fb := newFakeBucket(map[string][]byte{
+		"arr/.zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [4, 6],
+			"chunks": [2, 2],
+			"dtype": "<f4",
+			"compressor": null,
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+		"arr/.zattrs": []byte(`{}`),
+		"arr/1.2":     encodeLE(t, []float32{1, 2, 3, 4}),
+		"arr/0.1":     encodeLE(t, []float32{5, 6}),
+		"arr/5.0":     encodeLE(t, []float32{7}), // outside the grid
+		"arr/sub/0.0": []byte("nested"),
+		"other/0.0":   []byte("sibling"),
+	})
+	reader := openFakeArray(t, fb, "arr")
+
+	entries, err := reader.ChunkManifest(context.Background())
+	if err != nil {
+		t.Fatalf("ChunkManifest failed: %v", err)
+	}
+	if n := fb.readCount(); n != 0 {
+		t.Errorf("expected no object reads, got %d", n)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+	sum := md5.Sum(encodeLE(t, []float32{5, 6}))
+	first := entries[0]
+	if first.Key != "0.1" || !reflect.DeepEqual(first.Coords, []int{0, 1}) || first.Size != 8 || !reflect.DeepEqual(first.MD5, sum[:]) {
+		t.Errorf("unexpected first entry: %+v", first)
+	}
+	if second := entries[1]; second.Key != "1.2" || !reflect.DeepEqual(second.Coords, []int{1, 2}) || second.Size != 16 {
+		t.Errorf("unexpected second entry: %+v", second)
+	}
+}
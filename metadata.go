@@ -1,18 +1,44 @@
 package zarr
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"reflect"
 	"strconv"
+	"strings"
 )
 
-// CompressorConfig represents the Zarr compressor metadata.
+// CompressorConfig represents the Zarr compressor or filter codec metadata.
 type CompressorConfig struct {
 	ID      string `json:"id"`
 	Cname   string `json:"cname,omitempty"`
 	Clevel  int    `json:"clevel,omitempty"`
 	Shuffle int    `json:"shuffle,omitempty"`
+	// Blocksize is the blosc block size in bytes, 0 for automatic.
+	Blocksize int `json:"blocksize,omitempty"`
+	// Level is the compression level of the gzip, zlib and zstd codecs.
+	Level int `json:"level,omitempty"`
+}
+
+// MarshalJSON encodes the config, writing every blosc field even when it is
+// zero. numcodecs fills in missing blosc fields with non-zero defaults, such
+// as shuffle 1, so leaving out a zero would change the config's meaning.
+func (c CompressorConfig) MarshalJSON() ([]byte, error) {
+	type plain CompressorConfig
+	if c.ID != "blosc" {
+		return json.Marshal(plain(c))
+	}
+	return json.Marshal(struct {
+		ID        string `json:"id"`
+		Cname     string `json:"cname"`
+		Clevel    int    `json:"clevel"`
+		Shuffle   int    `json:"shuffle"`
+		Blocksize int    `json:"blocksize"`
+	}{c.ID, c.Cname, c.Clevel, c.Shuffle, c.Blocksize})
 }
 
 // Metadata represents the Zarr V2 .zarray metadata.
@@ -22,8 +48,63 @@ type Metadata struct {
 	Chunks     []int             `json:"chunks"`
 	DType      string            `json:"dtype"`
 	Compressor *CompressorConfig `json:"compressor"`
-	FillValue  interface{}       `json:"fill_value"`
-	Order      string            `json:"order"`
+	// FillValue holds numbers as json.Number when the metadata is decoded
+	// from JSON. Any Go integer, float or complex value may be set in code.
+	FillValue interface{}         `json:"fill_value"`
+	Order     string              `json:"order"`
+	Filters   []*CompressorConfig `json:"filters"`
+
+	// DimensionSeparator is "." (the default when empty) or "/".
+	DimensionSeparator string `json:"dimension_separator,omitempty"`
+
+	// Structured describes a structured dtype, stored as a list of fields
+	// in the dtype field. DType is then "|V<n>" for records of n bytes,
+	// which reads return undecoded.
+	Structured *StructuredDType `json:"-"`
+}
+
+// UnmarshalJSON decodes .zarray metadata, accepting the list form of
+// structured dtypes as well as dtype strings.
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	type plain Metadata
+	var aux struct {
+		plain
+		DType json.RawMessage `json:"dtype"`
+	}
+	// Keep numbers as json.Number so that integer fill values beyond 2^53
+	// stay exact.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&aux); err != nil {
+		return err
+	}
+	*m = Metadata(aux.plain)
+	if !isJSONList(aux.DType) {
+		if len(aux.DType) == 0 {
+			return nil
+		}
+		return json.Unmarshal(aux.DType, &m.DType)
+	}
+	structured, err := ParseStructuredDType(aux.DType)
+	if err != nil {
+		return err
+	}
+	m.Structured = structured
+	m.DType = fmt.Sprintf("|V%d", structured.ItemSize)
+	return nil
+}
+
+// MarshalJSON encodes the metadata, writing a structured dtype in its list
+// form.
+func (m Metadata) MarshalJSON() ([]byte, error) {
+	type plain Metadata
+	if m.Structured == nil {
+		return json.Marshal(plain(m))
+	}
+	return json.Marshal(struct {
+		plain
+		DType *StructuredDType `json:"dtype"`
+	}{plain(m), m.Structured})
 }
 
 // LoadMetadata reads and parses the .zarray file from the given directory path.
@@ -33,32 +114,191 @@ func LoadMetadata(reader io.Reader) (*Metadata, error) {
 		return nil, fmt.Errorf("failed to decode metadata: %w", err)
 	}
 
-	if meta.ZarrFormat != 2 {
-		return nil, fmt.Errorf("unsupported zarr_format: %d, expected 2", meta.ZarrFormat)
+	if err := meta.Validate(); err != nil {
+		return nil, err
 	}
-
 	return &meta, nil
 }
 
+// Validate checks the structural consistency of the metadata: a supported
+// zarr_format, shape and chunks of equal rank with positive chunk sizes, and
+// a well-formed dtype. Numeric dtypes must be understood by ParseDType;
+// other kinds, such as strings, objects or opaque records, are accepted so
+// that they can be read with ReadStrings, ReadObjects or WithRawDType.
+// LoadMetadata, and so every Reader, validates the metadata it loads.
+func (m *Metadata) Validate() error {
+	if m.ZarrFormat != 2 {
+		return fmt.Errorf("unsupported zarr_format: %d, expected 2", m.ZarrFormat)
+	}
+
+	// Every grid and stride computation indexes shape and chunks in step,
+	// so reject metadata that would make them panic later.
+	if len(m.Shape) == 0 && len(m.Chunks) > 0 {
+		return fmt.Errorf("chunks %v given for a scalar array with empty shape", m.Chunks)
+	}
+	if len(m.Chunks) != len(m.Shape) {
+		return fmt.Errorf("chunks %v has rank %d but shape %v has rank %d", m.Chunks, len(m.Chunks), m.Shape, len(m.Shape))
+	}
+	if m.Order != "" && m.Order != "C" && m.Order != "F" {
+		return fmt.Errorf("unsupported order %q, expected \"C\" or \"F\"", m.Order)
+	}
+	if sep := m.DimensionSeparator; sep != "" && sep != "." && sep != "/" {
+		return fmt.Errorf("unsupported dimension_separator %q", sep)
+	}
+	if err := validateDType(m.DType); err != nil {
+		return err
+	}
+	for i := range m.Shape {
+		if m.Shape[i] < 0 {
+			return fmt.Errorf("negative shape %d at dimension %d", m.Shape[i], i)
+		}
+		if m.Chunks[i] <= 0 {
+			return fmt.Errorf("chunk size must be positive, got %d at dimension %d", m.Chunks[i], i)
+		}
+	}
+	return nil
+}
+
+// MarshalZArray encodes the metadata as the contents of a .zarray file,
+// indented like the files zarr-python writes. Unset fields take their
+// defaults: zarr_format 2, order "C" and dimension_separator ".". NaN and
+// infinite fill values are written as the strings "NaN", "Infinity" and
+// "-Infinity", as JSON has no literals for them, and complex ones as
+// [real, imag] pairs.
+func (m *Metadata) MarshalZArray() ([]byte, error) {
+	out := *m
+	if out.ZarrFormat == 0 {
+		out.ZarrFormat = 2
+	}
+	if out.Order == "" {
+		out.Order = "C"
+	}
+	if out.DimensionSeparator == "" {
+		out.DimensionSeparator = "."
+	}
+	switch v := out.FillValue.(type) {
+	case float64:
+		out.FillValue = jsonFloat(v)
+	case float32:
+		out.FillValue = jsonFloat(float64(v))
+	case complex128:
+		out.FillValue = []any{jsonFloat(real(v)), jsonFloat(imag(v))}
+	case complex64:
+		out.FillValue = []any{jsonFloat(float64(real(v))), jsonFloat(float64(imag(v)))}
+	}
+	data, err := json.MarshalIndent(&out, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	return data, nil
+}
+
+// jsonFloat returns f, or its Zarr string spelling if JSON cannot hold it.
+func jsonFloat(f float64) any {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	return f
+}
+
+// validateDType checks that a dtype string names a byte order and, for the
+// numeric kinds, a size ParseDType accepts.
+func validateDType(s string) error {
+	if s == "" {
+		return fmt.Errorf("missing dtype")
+	}
+	if s[0] != '<' && s[0] != '>' && s[0] != '|' {
+		return fmt.Errorf("invalid byte order in dtype: %s", s)
+	}
+	if len(s) > 1 && strings.IndexByte("biufc", s[1]) >= 0 {
+		if _, _, err := ParseDType(s); err != nil {
+			return fmt.Errorf("invalid dtype: %w", err)
+		}
+	}
+	return nil
+}
+
+// DType is a numpy-style dtype string such as "<f4", as stored in
+// Metadata.DType.
+type DType string
+
+// Little-endian dtypes with a Go element type. The big-endian forms, such
+// as ">f4", decode to the same types.
+const (
+	Bool       DType = "|b1"
+	Int8       DType = "|i1"
+	Int16      DType = "<i2"
+	Int32      DType = "<i4"
+	Int64      DType = "<i8"
+	Uint8      DType = "|u1"
+	Uint16     DType = "<u2"
+	Uint32     DType = "<u4"
+	Uint64     DType = "<u8"
+	Float32    DType = "<f4"
+	Float64    DType = "<f8"
+	Complex64  DType = "<c8"
+	Complex128 DType = "<c16"
+)
+
+// Size returns the size in bytes of one element of the dtype, or 0 if the
+// dtype is not supported by ParseDType.
+func (d DType) Size() int {
+	_, size, err := ParseDType(string(d))
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// ReflectType returns the Go type the dtype's elements decode to, e.g.
+// float32 for "<f4" or ">f4", or nil if there is none.
+func (d DType) ReflectType() reflect.Type {
+	name, _, err := ParseDType(string(d))
+	if err != nil {
+		return nil
+	}
+	return goTypes[name]
+}
+
+// Order is the element order of an array's bytes, as stored in
+// Metadata.Order.
+type Order string
+
+const (
+	// OrderC is row-major order: the last dimension varies fastest.
+	OrderC Order = "C"
+	// OrderF is column-major (Fortran) order: the first dimension varies
+	// fastest.
+	OrderF Order = "F"
+)
+
 // ParseDType takes a numpy-style string like "<f4", "|b1", "<i8",
 // and returns a simplified string name (e.g., "float32", "bool", "int64"),
 // the byte size (e.g., 4, 1, 8), and an error if unsupported.
-// Reject big-endian (>) types for now.
+// Big-endian (>) types map to the same names; see DTypeByteOrder. The name
+// is the key to the Go type returned by DType.ReflectType, and the size is
+// what DType.Size returns.
 func ParseDType(s string) (string, int, error) {
 	if len(s) < 3 {
 		return "", 0, fmt.Errorf("invalid dtype: %s", s)
 	}
 
 	endian := s[0]
-	if endian == '>' {
-		return "", 0, fmt.Errorf("big-endian types are unsupported: %s", s)
+	if endian != '<' && endian != '>' && endian != '|' {
+		return "", 0, fmt.Errorf("invalid byte order in dtype: %s", s)
 	}
 
 	kind := s[1]
 	sizeStr := s[2:]
 
 	size, err := strconv.Atoi(sizeStr)
-	if err != nil {
+	if err != nil || size <= 0 {
+		// Zero-width numeric items would make element counts divide by zero.
 		return "", 0, fmt.Errorf("invalid size in dtype: %s", s)
 	}
 
@@ -77,3 +317,22 @@ func ParseDType(s string) (string, int, error) {
 		return "", 0, fmt.Errorf("unsupported dtype kind: %c in %s", kind, s)
 	}
 }
+
+// DTypeByteOrder returns the byte order of a numpy-style dtype string:
+// binary.BigEndian for ">" and binary.LittleEndian for "<" and for "|"
+// (single-byte types, for which the order does not matter). The Reader
+// always returns little-endian data, swapping big-endian chunks as they are
+// read.
+func DTypeByteOrder(s string) (binary.ByteOrder, error) {
+	if s == "" {
+		return nil, fmt.Errorf("invalid dtype: %s", s)
+	}
+	switch s[0] {
+	case '>':
+		return binary.BigEndian, nil
+	case '<', '|':
+		return binary.LittleEndian, nil
+	default:
+		return nil, fmt.Errorf("invalid byte order in dtype: %s", s)
+	}
+}
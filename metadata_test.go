@@ -1,9 +1,15 @@
 package zarr_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/TuSKan/go-zarr"
@@ -19,10 +25,11 @@ func TestParseDType(t *testing.T) {
 		{"<f4", "float32", 4, false},
 		{"<i8", "int64", 8, false},
 		{"|b1", "bool", 1, false},
-		{">f4", "", 0, true}, // big-endian should fail
-		{"x2", "", 0, true},  // invalid encoding
-		{"<x4", "", 0, true}, // unknown kind
-		{"<i", "", 0, true},  // incomplete size
+		{">f4", "float32", 4, false}, // big-endian, see DTypeByteOrder
+		{"x2", "", 0, true},          // invalid encoding
+		{"<x4", "", 0, true},         // unknown kind
+		{"<i", "", 0, true},          // incomplete size
+		{"<f0", "", 0, true},         // zero width
 	}
 
 	for _, tt := range tests {
@@ -48,6 +55,96 @@ func TestParseDType(t *testing.T) {
 	}
 }
 
+func TestDType_SizeAndReflectType(t *testing.T) {
+	tests := []struct {
+		dtype zarr.DType
+		size  int
+		typ   reflect.Type
+	}{
+		{"|b1", 1, reflect.TypeOf(false)},
+		{"|i1", 1, reflect.TypeOf(int8(0))},
+		{"<i2", 2, reflect.TypeOf(int16(0))},
+		{"<i4", 4, reflect.TypeOf(int32(0))},
+		{">i8", 8, reflect.TypeOf(int64(0))},
+		{"|u1", 1, reflect.TypeOf(uint8(0))},
+		{">u2", 2, reflect.TypeOf(uint16(0))},
+		{"<u4", 4, reflect.TypeOf(uint32(0))},
+		{"<u8", 8, reflect.TypeOf(uint64(0))},
+		{">f4", 4, reflect.TypeOf(float32(0))},
+		{"<f8", 8, reflect.TypeOf(float64(0))},
+		{"<c8", 8, reflect.TypeOf(complex64(0))},
+		{">c16", 16, reflect.TypeOf(complex128(0))},
+		// Sizes numpy has but Go has no type for.
+		{"<f2", 2, nil},
+		{"<i3", 3, nil},
+		// Unsupported dtypes.
+		{"|S10", 0, nil},
+		{"<x4", 0, nil},
+		{"f4", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.dtype), func(t *testing.T) {
+			if got := tt.dtype.Size(); got != tt.size {
+				t.Errorf("Size() = %d, want %d", got, tt.size)
+			}
+			if got := tt.dtype.ReflectType(); got != tt.typ {
+				t.Errorf("ReflectType() = %v, want %v", got, tt.typ)
+			}
+		})
+	}
+}
+
+func TestDTypeByteOrder(t *testing.T) {
+	for input, want := range map[string]binary.ByteOrder{
+		"<f8": binary.LittleEndian,
+		">i2": binary.BigEndian,
+		"|b1": binary.LittleEndian,
+	} {
+		got, err := zarr.DTypeByteOrder(input)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", input, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%q: expected %v, got %v", input, want, got)
+		}
+	}
+	if _, err := zarr.DTypeByteOrder("=f4"); err == nil {
+		t.Error("expected an error for an unknown byte order")
+	}
+}
+
+func TestCompressorConfig_BloscRoundTrip(t *testing.T) {
+	for _, raw := range []string{
+		`{"blocksize":0,"clevel":5,"cname":"lz4","id":"blosc","shuffle":1}`,
+		`{"blocksize":262144,"clevel":0,"cname":"zstd","id":"blosc","shuffle":0}`,
+	} {
+		var cfg zarr.CompressorConfig
+		if err := json.Unmarshal([]byte(raw), &cfg); err != nil {
+			t.Fatalf("Unmarshal(%s) failed: %v", raw, err)
+		}
+		out, err := json.Marshal(&cfg)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var want, got map[string]any
+		json.Unmarshal([]byte(raw), &want)
+		json.Unmarshal(out, &got)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("round trip of %s gave %s", raw, out)
+		}
+	}
+
+	out, err := json.Marshal(zarr.CompressorConfig{ID: "gzip", Level: 1})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(out) != `{"id":"gzip","level":1}` {
+		t.Errorf("expected only the gzip fields, got %s", out)
+	}
+}
+
 func TestLoadMetadata(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -95,3 +192,158 @@ func TestLoadMetadata(t *testing.T) {
 		t.Errorf("expected dtype <f4, got %s", meta.DType)
 	}
 }
+
+func TestMetadata_Validate(t *testing.T) {
+	valid := func() zarr.Metadata {
+		return zarr.Metadata{ZarrFormat: 2, Shape: []int{4, 4}, Chunks: []int{2, 2}, DType: "<f4", Order: "C"}
+	}
+	tests := []struct {
+		name   string
+		modify func(m *zarr.Metadata)
+		want   string
+	}{
+		{"valid", func(m *zarr.Metadata) {}, ""},
+		{"scalar", func(m *zarr.Metadata) { m.Shape, m.Chunks = nil, nil }, ""},
+		{"string dtype", func(m *zarr.Metadata) { m.DType = "|S8" }, ""},
+		{"object dtype", func(m *zarr.Metadata) { m.DType = "|O" }, ""},
+		{"opaque dtype", func(m *zarr.Metadata) { m.DType = "|V3" }, ""},
+		{"zarr_format", func(m *zarr.Metadata) { m.ZarrFormat = 3 }, "zarr_format"},
+		{"rank mismatch", func(m *zarr.Metadata) { m.Chunks = []int{2} }, "rank"},
+		{"zero chunk", func(m *zarr.Metadata) { m.Chunks = []int{2, 0} }, "chunk size must be positive"},
+		{"negative chunk", func(m *zarr.Metadata) { m.Chunks = []int{-2, 2} }, "chunk size must be positive"},
+		{"negative shape", func(m *zarr.Metadata) { m.Shape = []int{4, -1} }, "negative shape"},
+		{"missing dtype", func(m *zarr.Metadata) { m.DType = "" }, "missing dtype"},
+		{"byte order", func(m *zarr.Metadata) { m.DType = "f4" }, "byte order"},
+		{"numeric size", func(m *zarr.Metadata) { m.DType = "<fX" }, "invalid size"},
+		{"zero size", func(m *zarr.Metadata) { m.DType = "<i0" }, "invalid size"},
+		{"order", func(m *zarr.Metadata) { m.Order = "K" }, "order"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid()
+			tt.modify(&m)
+			err := m.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("expected valid metadata, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error mentioning %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestNewReader_InvalidChunkGrid(t *testing.T) {
+	tests := []struct {
+		name   string
+		shape  string
+		chunks string
+		want   string
+	}{
+		{"rank mismatch", "[4, 4]", "[2]", "rank"},
+		{"zero chunk", "[4, 4]", "[2, 0]", "chunk size must be positive"},
+		{"negative shape", "[-1]", "[1]", "negative shape"},
+		{"chunked scalar", "[]", "[2]", "scalar array"},
+		{"unchunked shape", "[4]", "[]", "rank"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeArray(t, dir, `{
+				"zarr_format": 2,
+				"shape": `+tt.shape+`,
+				"chunks": `+tt.chunks+`,
+				"dtype": "<f4",
+				"compressor": null,
+				"fill_value": 0.0,
+				"order": "C"
+			}`, nil)
+
+			_, err := zarr.NewReader(context.Background(), "file:///"+filepath.ToSlash(dir))
+			if err == nil {
+				t.Fatal("expected NewReader to reject the metadata")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error mentioning %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestNewReader_InvalidDType(t *testing.T) {
+	dir := t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [4],
+		"chunks": [2],
+		"dtype": "<f0",
+		"compressor": null,
+		"fill_value": 0.0,
+		"order": "C"
+	}`, nil)
+
+	_, err := zarr.NewReader(context.Background(), "file:///"+filepath.ToSlash(dir))
+	if err == nil || !strings.Contains(err.Error(), "invalid size in dtype") {
+		t.Errorf("expected NewReader to reject the dtype, got %v", err)
+	}
+}
+
+func TestMetadata_MarshalZArray(t *testing.T) {
+	meta := &zarr.Metadata{
+		Shape:      []int{10, 20},
+		Chunks:     []int{5, 5},
+		DType:      "<f8",
+		Compressor: &zarr.CompressorConfig{ID: "zstd", Level: 3},
+		FillValue:  math.NaN(),
+	}
+	data, err := meta.MarshalZArray()
+	if err != nil {
+		t.Fatalf("MarshalZArray failed: %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, data)
+	}
+	for _, key := range []string{"zarr_format", "shape", "chunks", "dtype", "compressor", "fill_value", "order", "filters", "dimension_separator"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("missing %s in %s", key, data)
+		}
+	}
+	if fields["fill_value"] != "NaN" {
+		t.Errorf("expected fill_value \"NaN\", got %v", fields["fill_value"])
+	}
+
+	loaded, err := zarr.LoadMetadata(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadMetadata failed: %v", err)
+	}
+	if loaded.ZarrFormat != 2 || loaded.Order != "C" || loaded.DimensionSeparator != "." {
+		t.Errorf("expected defaults 2, C and \".\", got %d, %s and %q", loaded.ZarrFormat, loaded.Order, loaded.DimensionSeparator)
+	}
+	if !reflect.DeepEqual(loaded.Shape, meta.Shape) || !reflect.DeepEqual(loaded.Chunks, meta.Chunks) || loaded.DType != meta.DType {
+		t.Errorf("layout changed in the round trip: %+v", loaded)
+	}
+	if *loaded.Compressor != *meta.Compressor {
+		t.Errorf("expected compressor %+v, got %+v", *meta.Compressor, *loaded.Compressor)
+	}
+	if meta.Order != "" || !math.IsNaN(meta.FillValue.(float64)) {
+		t.Error("MarshalZArray modified the metadata")
+	}
+
+	for fill, want := range map[float64]string{math.Inf(1): `"Infinity"`, math.Inf(-1): `"-Infinity"`, 1.5: `1.5`} {
+		meta.FillValue = fill
+		data, err := meta.MarshalZArray()
+		if err != nil {
+			t.Fatalf("MarshalZArray failed: %v", err)
+		}
+		if !strings.Contains(string(data), `"fill_value": `+want) {
+			t.Errorf("expected fill_value %s in %s", want, data)
+		}
+	}
+}
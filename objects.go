@@ -0,0 +1,163 @@
+package zarr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"unsafe"
+)
+
+// ReadObjects reads an object array ("|O" dtype) whose elements were
+// serialized with the numcodecs json2 codec, returning the elements in C
+// order of the reader's view. Elements are decoded as by encoding/json, so
+// nested lists and mappings become []any and map[string]any and JSON null
+// becomes nil. Elements of missing chunks take the array's fill value.
+// WithMaxReadBytes counts each element as the size of an interface value,
+// not including what it refers to.
+func (r *Reader) ReadObjects(ctx context.Context, opts ...ReadOption) ([]any, error) {
+	if r.viewErr != nil {
+		return nil, r.viewErr
+	}
+	if r.meta.DType != "|O" {
+		return nil, fmt.Errorf("ReadObjects requires an object dtype, got %s", r.meta.DType)
+	}
+	if err := r.checkObjectCodec(); err != nil {
+		return nil, err
+	}
+	o := newReadOptions(opts)
+
+	elemSize := int(unsafe.Sizeof(any(nil)))
+	size, err := byteSize(r.meta.Shape, elemSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkReadSize(size); err != nil {
+		return nil, err
+	}
+	grid := GridShape(r.meta.Shape, r.meta.Chunks)
+	if err := r.checkChunkCount(grid); err != nil {
+		return nil, err
+	}
+
+	fill := r.meta.FillValue
+	if n, ok := fill.(json.Number); ok {
+		// Match the float64 numbers decodeJSON2 yields for elements.
+		fill, _ = n.Float64()
+	}
+	out := make([]any, size/elemSize)
+	for i := range out {
+		out[i] = fill
+	}
+
+	// Place elements in the view's C order: a transposed axis takes the
+	// stride of the view axis it is presented as, and a flipped axis is
+	// written back to front.
+	viewStrides := strides(r.Shape())
+	dstStrides := make([]int, len(viewStrides))
+	base := 0
+	for j, stride := range viewStrides {
+		i := r.storageAxis(j)
+		dstStrides[i] = stride
+		if r.isFlipped(i) {
+			dstStrides[i] = -stride
+			base += (r.meta.Shape[i] - 1) * stride
+		}
+	}
+
+	last := make([]int, len(grid))
+	for i, n := range grid {
+		last[i] = n - 1
+	}
+	err = r.visitChunks(ctx, make([]int, len(grid)), last, o.chunkOrder, func(ctx context.Context, coords []int) error {
+		data, found, err := r.fetchChunk(ctx, coords, o)
+		if err != nil || !found {
+			return err
+		}
+		items, err := decodeJSON2(data, len(r.meta.Chunks))
+		if err != nil {
+			return fmt.Errorf("chunk %s: %w", r.chunkKey(coords), err)
+		}
+		return scatterObjects(out, items, coords, r.meta.Shape, r.meta.Chunks, base, dstStrides)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// checkObjectCodec verifies that the array's filters decode objects with a
+// supported codec.
+func (r *Reader) checkObjectCodec() error {
+	for _, f := range r.meta.Filters {
+		switch f.ID {
+		case "json2":
+			return nil
+		case "msgpack2", "pickle", "vlen-utf8", "vlen-bytes", "vlen-array":
+			return fmt.Errorf("unsupported object codec: %s", f.ID)
+		}
+	}
+	return fmt.Errorf("object array has no json2 filter")
+}
+
+// decodeJSON2 decodes a chunk written by the numcodecs json2 codec. The codec
+// stores the chunk as a (nested) JSON list followed by the dtype string and
+// the chunk shape; the elements are returned flattened to C order.
+func decodeJSON2(data []byte, rank int) ([]any, error) {
+	var items []any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := dec.Decode(&items); err != nil {
+		return nil, fmt.Errorf("failed to decode json2 chunk: %w", err)
+	}
+	if len(items) < 2 {
+		return nil, fmt.Errorf("json2 chunk is missing its dtype and shape trailer")
+	}
+	items = items[:len(items)-2]
+
+	// The elements are nested one list level per dimension beyond the first.
+	for depth := 1; depth < rank; depth++ {
+		var flat []any
+		for _, item := range items {
+			row, ok := item.([]any)
+			if !ok {
+				return nil, fmt.Errorf("json2 chunk is not nested to rank %d", rank)
+			}
+			flat = append(flat, row...)
+		}
+		items = flat
+	}
+	return items, nil
+}
+
+// scatterObjects copies the in-bounds elements of a decoded chunk into out,
+// where the element at global coordinates g lands at base + sum g[i] *
+// dstStrides[i]. json2 lists the elements of a chunk in C order whatever the
+// array's memory order.
+func scatterObjects(out, items []any, coords, shape, chunks []int, base int, dstStrides []int) error {
+	chunkSize := 1
+	for _, c := range chunks {
+		chunkSize *= c
+	}
+	if len(items) != chunkSize {
+		return fmt.Errorf("json2 chunk has %d elements, expected %d", len(items), chunkSize)
+	}
+
+	itemStrides := strides(chunks)
+	for n, item := range items {
+		idx := base
+		inBounds := true
+		for i := range chunks {
+			rel := (n / itemStrides[i]) % chunks[i]
+			g := coords[i]*chunks[i] + rel
+			if g >= shape[i] {
+				inBounds = false
+				break
+			}
+			idx += g * dstStrides[i]
+		}
+		if inBounds {
+			out[idx] = item
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package zarr_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_ReadObjects(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("1D", func(t *testing.T) {
+		dir := t.TempDir()
+		writeArray(t, dir, `{
+			"zarr_format": 2,
+			"shape": [7],
+			"chunks": [3],
+			"dtype": "|O",
+			"compressor": null,
+			"fill_value": null,
+			"order": "C",
+			"filters": [{"id": "json2", "encoding": "utf-8"}]
+		}`, map[string][]byte{
+			"0": []byte(`[1, "a", null, "|O", [3]]`),
+			"2": []byte(`[{"k": [1, 2]}, "pad", "pad", "|O", [3]]`),
+		})
+		reader := openReader(t, dir)
+
+		got, err := reader.ReadObjects(ctx)
+		if err != nil {
+			t.Fatalf("ReadObjects failed: %v", err)
+		}
+		expected := []any{
+			float64(1), "a", nil,
+			nil, nil, nil, // missing chunk 1
+			map[string]any{"k": []any{float64(1), float64(2)}},
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("unexpected objects.\nExpected: %#v\nGot:      %#v", expected, got)
+		}
+	})
+
+	t.Run("2D", func(t *testing.T) {
+		dir := t.TempDir()
+		writeArray(t, dir, `{
+			"zarr_format": 2,
+			"shape": [2, 3],
+			"chunks": [2, 2],
+			"dtype": "|O",
+			"compressor": null,
+			"fill_value": "",
+			"order": "C",
+			"filters": [{"id": "json2"}]
+		}`, map[string][]byte{
+			"0.0": []byte(`[["a", ["b"]], [null, "d"], "|O", [2, 2]]`),
+		})
+		reader := openReader(t, dir)
+
+		got, err := reader.ReadObjects(ctx)
+		if err != nil {
+			t.Fatalf("ReadObjects failed: %v", err)
+		}
+		expected := []any{"a", []any{"b"}, "", nil, "d", ""}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("unexpected objects.\nExpected: %#v\nGot:      %#v", expected, got)
+		}
+	})
+
+	t.Run("unsupported codec", func(t *testing.T) {
+		dir := t.TempDir()
+		writeArray(t, dir, `{
+			"zarr_format": 2,
+			"shape": [2],
+			"chunks": [2],
+			"dtype": "|O",
+			"compressor": null,
+			"fill_value": null,
+			"order": "C",
+			"filters": [{"id": "msgpack2"}]
+		}`, nil)
+		reader := openReader(t, dir)
+
+		if _, err := reader.ReadObjects(ctx); err == nil {
+			t.Error("expected an error for the msgpack2 codec")
+		}
+	})
+
+	objects2x3 := func(t *testing.T, order string) *zarr.Reader {
+		dir := t.TempDir()
+		writeArray(t, dir, `{
+			"zarr_format": 2,
+			"shape": [2, 3],
+			"chunks": [2, 2],
+			"dtype": "|O",
+			"compressor": null,
+			"fill_value": null,
+			"order": "`+order+`",
+			"filters": [{"id": "json2"}]
+		}`, map[string][]byte{
+			"0.0": []byte(`[["a", "b"], ["d", "e"], "|O", [2, 2]]`),
+			"0.1": []byte(`[["c", null], ["f", null], "|O", [2, 2]]`),
+		})
+		return openReader(t, dir)
+	}
+
+	t.Run("order F", func(t *testing.T) {
+		// json2 lists chunk elements in C order even for "F" arrays.
+		got, err := objects2x3(t, "F").ReadObjects(ctx)
+		if err != nil {
+			t.Fatalf("ReadObjects failed: %v", err)
+		}
+		expected := []any{"a", "b", "c", "d", "e", "f"}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("unexpected objects.\nExpected: %#v\nGot:      %#v", expected, got)
+		}
+	})
+
+	t.Run("views", func(t *testing.T) {
+		reader := objects2x3(t, "C")
+		for _, tc := range []struct {
+			name     string
+			view     *zarr.Reader
+			expected []any
+		}{
+			{"transpose", reader.Transpose([]int{1, 0}), []any{"a", "d", "b", "e", "c", "f"}},
+			{"flip", reader.Flip([]int{1}), []any{"c", "b", "a", "f", "e", "d"}},
+			{"both", reader.Transpose([]int{1, 0}).Flip([]int{1}), []any{"d", "a", "e", "b", "f", "c"}},
+		} {
+			got, err := tc.view.ReadObjects(ctx)
+			if err != nil {
+				t.Fatalf("%s: ReadObjects failed: %v", tc.name, err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("%s: unexpected objects.\nExpected: %#v\nGot:      %#v", tc.name, tc.expected, got)
+			}
+		}
+
+		if _, err := reader.Flip([]int{2}).ReadObjects(ctx); err == nil {
+			t.Error("expected the invalid view's error")
+		}
+	})
+
+	t.Run("read limit", func(t *testing.T) {
+		_, err := objects2x3(t, "C").WithMaxReadBytes(16).ReadObjects(ctx)
+		if !errors.Is(err, zarr.ErrReadLimitExceeded) {
+			t.Errorf("expected ErrReadLimitExceeded, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package zarr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Node is an array or a group, as returned by Open. Type-switch on *Reader
+// and *Group to use the rest of their API.
+type Node interface {
+	// Path returns the node's key prefix within the bucket, without a
+	// trailing slash; the root has an empty path.
+	Path() string
+	// Attributes returns the node's .zattrs.
+	Attributes(ctx context.Context) (map[string]any, error)
+	// Close releases the node's reference to the bucket.
+	Close() error
+}
+
+// Open opens the bucket at the given gocloud URL and whatever is stored at
+// its root: a *Reader if it holds a .zarray file, or a *Group if it holds a
+// .zgroup file. Closing the node closes the bucket once everything opened
+// from it has been closed too.
+func Open(ctx context.Context, path string) (Node, error) {
+	store, err := OpenSharedBucket(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	node, err := store.Open(ctx, "")
+	// The node holds its own reference, as for NewReader.
+	store.Close()
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// Open opens the array or group stored under the given key prefix, telling
+// them apart by their metadata file. Zarr V3 nodes, marked by a zarr.json
+// file, are recognized but not supported.
+func (s *SharedBucket) Open(ctx context.Context, path string) (Node, error) {
+	prefix := keyPrefix(path)
+	for _, file := range []string{".zarray", ".zgroup", "zarr.json"} {
+		ok, err := s.bucket.Exists(ctx, prefix+file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s%s: %w", prefix, file, err)
+		}
+		if !ok {
+			continue
+		}
+		switch file {
+		case ".zarray":
+			return s.OpenArray(ctx, path)
+		case ".zgroup":
+			return s.OpenGroup(ctx, path)
+		default:
+			return nil, fmt.Errorf("found zarr.json at %q: Zarr V3 is not supported", path)
+		}
+	}
+	return nil, fmt.Errorf("no .zarray, .zgroup or zarr.json at %q", path)
+}
+
+// Path returns the array's key prefix within the bucket, without a trailing
+// slash; an array at the bucket root has an empty path.
+func (r *Reader) Path() string {
+	return strings.TrimSuffix(r.prefix, "/")
+}
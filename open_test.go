@@ -0,0 +1,75 @@
+package zarr_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	writeGroup(t, dir)
+	writeFloat32Array(t, filepath.Join(dir, "a"), sequential4x4, map[string][]float32{
+		"0.0": {0, 1, 4, 5},
+	})
+	writeGroup(t, filepath.Join(dir, "labels"))
+	if err := os.MkdirAll(filepath.Join(dir, "v3"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "v3", "zarr.json"), []byte(`{"zarr_format": 3, "node_type": "array"}`), 0644)
+	ctx := context.Background()
+	url := "file:///" + filepath.ToSlash(dir)
+
+	root, err := zarr.Open(ctx, url)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer root.Close()
+	if _, ok := root.(*zarr.Group); !ok || root.Path() != "" {
+		t.Errorf("expected the root group, got %T at %q", root, root.Path())
+	}
+
+	array, err := zarr.Open(ctx, url+"/a")
+	if err != nil {
+		t.Fatalf("Open of an array failed: %v", err)
+	}
+	defer array.Close()
+	reader, ok := array.(*zarr.Reader)
+	if !ok {
+		t.Fatalf("expected a *zarr.Reader, got %T", array)
+	}
+	data, err := reader.ReadChunk(ctx, []int{0, 0})
+	if err != nil {
+		t.Fatalf("ReadChunk failed: %v", err)
+	}
+	if got := decodeFloat32(data); got[3] != 5 {
+		t.Errorf("unexpected chunk %v", got)
+	}
+
+	store, err := zarr.OpenSharedBucket(ctx, url)
+	if err != nil {
+		t.Fatalf("OpenSharedBucket failed: %v", err)
+	}
+	defer store.Close()
+	for path, isArray := range map[string]bool{"a": true, "labels": false} {
+		node, err := store.Open(ctx, path)
+		if err != nil {
+			t.Fatalf("Open(%s) failed: %v", path, err)
+		}
+		if _, ok := node.(*zarr.Reader); ok != isArray || node.Path() != path {
+			t.Errorf("Open(%s): got a %T at %q", path, node, node.Path())
+		}
+		node.Close()
+	}
+
+	if _, err := store.Open(ctx, "v3"); err == nil || !strings.Contains(err.Error(), "V3") {
+		t.Errorf("expected a Zarr V3 error, got %v", err)
+	}
+	if _, err := store.Open(ctx, "missing"); err == nil || !strings.Contains(err.Error(), "no .zarray") {
+		t.Errorf("expected an error naming the missing metadata, got %v", err)
+	}
+}
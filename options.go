@@ -0,0 +1,86 @@
+package zarr
+
+// NotFoundPolicy selects how a read treats chunks that are absent from the
+// store.
+type NotFoundPolicy int
+
+const (
+	// NotFoundFill substitutes the fill value for missing chunks. This is the
+	// default and matches the Zarr spec for sparse arrays.
+	NotFoundFill NotFoundPolicy = iota
+	// NotFoundError fails the read with ErrChunkNotFound, for consumers that
+	// need to assert an array is complete.
+	NotFoundError
+)
+
+// SizePolicy selects how a read treats chunks that decode to a different
+// number of bytes than a full chunk holds, as happens when the metadata's
+// chunks disagree with how the data was written or a chunk object was
+// truncated. Zarr V2 stores edge chunks at full size too, so every chunk is
+// expected to hold a full chunk's bytes.
+type SizePolicy int
+
+const (
+	// SizeLenient keeps the leading full-chunk bytes of an oversized chunk,
+	// fills the missing elements of a short chunk with the fill value, and
+	// logs a warning through the logger set by WithLogger in both cases.
+	// This is the default.
+	SizeLenient SizePolicy = iota
+	// SizeStrict fails the read with ErrChunkCorrupt.
+	SizeStrict
+)
+
+// ChunkOrder selects the order in which a read visits the chunks it touches.
+// It never changes the data returned, only the sequence of chunk requests.
+type ChunkOrder int
+
+const (
+	// ChunkOrderC visits chunks in row-major order of their grid
+	// coordinates. This is the default.
+	ChunkOrderC ChunkOrder = iota
+	// ChunkOrderMorton visits chunks along a Z-order (Morton) curve, which
+	// keeps consecutive chunks close together in every dimension and suits
+	// tiled consumers and caches better than row-major order.
+	ChunkOrderMorton
+)
+
+// ReadOption configures a single ReadChunk, ReadRegion or ReadFull call.
+type ReadOption func(*readOptions)
+
+type readOptions struct {
+	notFound   NotFoundPolicy
+	chunkOrder ChunkOrder
+	size       SizePolicy
+}
+
+// WithNotFoundPolicy sets how missing chunks are handled for this read.
+func WithNotFoundPolicy(policy NotFoundPolicy) ReadOption {
+	return func(o *readOptions) {
+		o.notFound = policy
+	}
+}
+
+// WithSizePolicy sets how oversized and short chunks are handled for this
+// read. Only chunks that are decoded in full are checked; range reads of
+// uncompressed chunks never look past the bytes they need.
+func WithSizePolicy(policy SizePolicy) ReadOption {
+	return func(o *readOptions) {
+		o.size = policy
+	}
+}
+
+// WithChunkOrder sets the order in which ReadFull and ReadRegion fetch
+// chunks for this read.
+func WithChunkOrder(order ChunkOrder) ReadOption {
+	return func(o *readOptions) {
+		o.chunkOrder = order
+	}
+}
+
+func newReadOptions(opts []ReadOption) readOptions {
+	var o readOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,234 @@
+package zarr_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_NotFoundPolicy(t *testing.T) {
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, sequential4x4, map[string][]float32{
+		"0.0": {0, 1, 4, 5},
+		"1.1": {10, 11, 14, 15},
+	})
+	reader := openReader(t, dir)
+	ctx := context.Background()
+	strict := zarr.WithNotFoundPolicy(zarr.NotFoundError)
+
+	// Default policy: missing chunks are filled.
+	if _, err := reader.ReadFull(ctx); err != nil {
+		t.Errorf("ReadFull with fill policy failed: %v", err)
+	}
+	if _, err := reader.ReadChunk(ctx, []int{0, 1}); err != nil {
+		t.Errorf("ReadChunk with fill policy failed: %v", err)
+	}
+	if _, err := reader.ReadRegion(ctx, []int{1, 1}, []int{2, 2}, zarr.WithNotFoundPolicy(zarr.NotFoundFill)); err != nil {
+		t.Errorf("ReadRegion with explicit fill policy failed: %v", err)
+	}
+
+	// Strict policy: any read touching a missing chunk fails.
+	if _, err := reader.ReadFull(ctx, strict); !errors.Is(err, zarr.ErrChunkNotFound) {
+		t.Errorf("ReadFull: expected ErrChunkNotFound, got %v", err)
+	}
+	if _, err := reader.ReadChunk(ctx, []int{0, 1}, strict); !errors.Is(err, zarr.ErrChunkNotFound) {
+		t.Errorf("ReadChunk: expected ErrChunkNotFound, got %v", err)
+	}
+	if _, err := reader.ReadRegion(ctx, []int{1, 1}, []int{2, 2}, strict); !errors.Is(err, zarr.ErrChunkNotFound) {
+		t.Errorf("ReadRegion: expected ErrChunkNotFound, got %v", err)
+	}
+
+	// Reads confined to present chunks succeed under the strict policy.
+	if _, err := reader.ReadChunk(ctx, []int{1, 1}, strict); err != nil {
+		t.Errorf("ReadChunk of a present chunk failed: %v", err)
+	}
+	if _, err := reader.ReadRegion(ctx, []int{0, 0}, []int{2, 2}, strict); err != nil {
+		t.Errorf("ReadRegion within a present chunk failed: %v", err)
+	}
+}
+
+func TestReader_ChunkOrderMorton(t *testing.T) {
+	fb, _ := gzipArray(t, 16, 4)
+	reader := openFake(t, fb)
+	ctx := context.Background()
+	morton := zarr.WithChunkOrder(zarr.ChunkOrderMorton)
+
+	want, err := reader.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+	fb.resetReads()
+
+	got, err := reader.ReadFull(ctx, morton)
+	if err != nil {
+		t.Fatalf("ReadFull in Morton order failed: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("ReadFull in Morton order returned different data")
+	}
+
+	var order []string
+	for _, r := range fb.reads {
+		order = append(order, r.Key)
+	}
+	prefix := []string{"0.0", "0.1", "1.0", "1.1", "0.2", "0.3", "1.2", "1.3", "2.0"}
+	if len(order) != 16 || !slices.Equal(order[:len(prefix)], prefix) {
+		t.Errorf("expected chunks in Z-order starting %v, got %v", prefix, order)
+	}
+
+	// Regions, including flipped views, are unaffected by the order.
+	flipped := reader.Flip([]int{1})
+	want, err = flipped.ReadRegion(ctx, []int{3, 2}, []int{9, 13})
+	if err != nil {
+		t.Fatalf("ReadRegion failed: %v", err)
+	}
+	got, err = flipped.ReadRegion(ctx, []int{3, 2}, []int{9, 13}, morton)
+	if err != nil {
+		t.Fatalf("ReadRegion in Morton order failed: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("ReadRegion in Morton order returned different data")
+	}
+}
+
+func BenchmarkReader_TiledReadChunkOrder(b *testing.B) {
+	fb, _ := gzipArray(b, 256, 16)
+	reader := openFake(b, fb)
+	ctx := context.Background()
+
+	for _, bm := range []struct {
+		name  string
+		order zarr.ChunkOrder
+	}{
+		{"C", zarr.ChunkOrderC},
+		{"Morton", zarr.ChunkOrderMorton},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				// Read 64x64 tiles, as a tiled renderer would.
+				for y := 0; y < 256; y += 64 {
+					for x := 0; x < 256; x += 64 {
+						if _, err := reader.ReadRegion(ctx, []int{y, x}, []int{64, 64}, zarr.WithChunkOrder(bm.order)); err != nil {
+							b.Fatal(err)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestReader_SizePolicy(t *testing.T) {
+	fb, _ := gzipArray(t, 4, 2)
+	// Chunk 0.1 decodes to six floats where a 2x2 chunk holds four.
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write(encodeLE(t, []float32{2, 3, 6, 7, 99, 99}))
+	w.Close()
+	fb.put("0.1", buf.Bytes())
+	h := &recordHandler{}
+	reader := openFake(t, fb).WithLogger(slog.New(h))
+	ctx := context.Background()
+
+	// Lenient by default: the leading chunk bytes are used and a warning
+	// is logged.
+	got, err := reader.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+	if want := []float32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}; !slices.Equal(decodeFloat32(got), want) {
+		t.Errorf("expected %v, got %v", want, decodeFloat32(got))
+	}
+	chunk, err := reader.ReadChunk(ctx, []int{0, 1})
+	if err != nil {
+		t.Fatalf("ReadChunk failed: %v", err)
+	}
+	if want := []float32{2, 3, 6, 7}; !slices.Equal(decodeFloat32(chunk), want) {
+		t.Errorf("expected the chunk trimmed to %v, got %v", want, decodeFloat32(chunk))
+	}
+	if warn := h.find("oversized chunk truncated")["0.1"]; warn["bytes"] != "24" || warn["expected"] != "16" {
+		t.Errorf("expected a warning for chunk 0.1, got %v", warn)
+	}
+
+	strict := zarr.WithSizePolicy(zarr.SizeStrict)
+	if _, err := reader.ReadFull(ctx, strict); !errors.Is(err, zarr.ErrChunkCorrupt) {
+		t.Errorf("ReadFull: expected ErrChunkCorrupt, got %v", err)
+	}
+	if _, err := reader.ReadChunk(ctx, []int{0, 1}, strict); !errors.Is(err, zarr.ErrChunkCorrupt) {
+		t.Errorf("ReadChunk: expected ErrChunkCorrupt, got %v", err)
+	}
+	if _, err := reader.ReadChunk(ctx, []int{1, 1}, strict); err != nil {
+		t.Errorf("ReadChunk of a well-sized chunk failed: %v", err)
+	}
+}
+
+func TestReader_SizePolicyShortChunk(t *testing.T) {
+	dir := t.TempDir()
+	// Chunk 0.0 was cut off two bytes into its fourth element.
+	truncated := encodeBE(t, []float32{0, 1, 4, 5})[:14]
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [4, 4],
+		"chunks": [2, 2],
+		"dtype": ">f4",
+		"compressor": null,
+		"fill_value": 7.0,
+		"order": "C"
+	}`, map[string][]byte{
+		"0.0": truncated,
+		"0.1": encodeBE(t, []float32{2, 3, 6, 7}),
+	})
+	h := &recordHandler{}
+	reader := openReader(t, dir).WithLogger(slog.New(h))
+	ctx := context.Background()
+
+	// Lenient by default: the missing elements hold the fill value.
+	for name, read := range map[string]func() ([]byte, error){
+		"ReadChunk":  func() ([]byte, error) { return reader.ReadChunk(ctx, []int{0, 0}) },
+		"ReadRegion": func() ([]byte, error) { return reader.ReadRegion(ctx, []int{0, 0}, []int{2, 2}) },
+	} {
+		got, err := read()
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if want := []float32{0, 1, 4, 7}; !slices.Equal(decodeFloat32(got), want) {
+			t.Errorf("%s: expected %v, got %v", name, want, decodeFloat32(got))
+		}
+	}
+	full, err := reader.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+	if got := decodeFloat32(full)[:8]; !slices.Equal(got, []float32{0, 1, 2, 3, 4, 7, 6, 7}) {
+		t.Errorf("expected the padded chunk in the first rows, got %v", got)
+	}
+	// A range read of the cut element alone fills it too.
+	elem, err := reader.ReadRegion(ctx, []int{1, 1}, []int{1, 1})
+	if err != nil {
+		t.Fatalf("ReadRegion of one element failed: %v", err)
+	}
+	if got := decodeFloat32(elem); got[0] != 7 {
+		t.Errorf("expected the fill value for the cut element, got %v", got)
+	}
+	if warn := h.find("short chunk padded with fill value")["0.0"]; warn["bytes"] != "14" || warn["expected"] != "16" {
+		t.Errorf("expected a warning for chunk 0.0, got %v", warn)
+	}
+
+	strict := zarr.WithSizePolicy(zarr.SizeStrict)
+	if _, err := reader.ReadFull(ctx, strict); !errors.Is(err, zarr.ErrChunkCorrupt) {
+		t.Errorf("ReadFull: expected ErrChunkCorrupt, got %v", err)
+	}
+	if _, err := reader.ReadChunk(ctx, []int{0, 0}, strict); !errors.Is(err, zarr.ErrChunkCorrupt) {
+		t.Errorf("ReadChunk: expected ErrChunkCorrupt, got %v", err)
+	}
+	if _, err := reader.ReadChunk(ctx, []int{0, 1}, strict); err != nil {
+		t.Errorf("ReadChunk of a full chunk failed: %v", err)
+	}
+}
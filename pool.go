@@ -0,0 +1,37 @@
+package zarr
+
+import "sync"
+
+// bufferPool recycles chunk-sized byte buffers between reads on a Reader and
+// its views. Buffers taken from the pool are owned by the caller until they
+// are put back, and only buffers that never escape to the user may be put
+// back: ReadChunk results, for instance, are never recycled.
+type bufferPool struct {
+	size int
+	pool sync.Pool
+}
+
+func newBufferPool(size int) *bufferPool {
+	return &bufferPool{size: size}
+}
+
+// get returns a buffer of length n. Its contents are undefined.
+func (p *bufferPool) get(n int) []byte {
+	if p == nil {
+		return make([]byte, n)
+	}
+	if b, ok := p.pool.Get().(*[]byte); ok && cap(*b) >= n {
+		return (*b)[:n]
+	}
+	return make([]byte, n, max(n, p.size))
+}
+
+// put makes b available to later get calls. Buffers smaller than the
+// expected chunk size are dropped so the pool does not fill with fragments.
+func (p *bufferPool) put(b []byte) {
+	if p == nil || cap(b) < p.size {
+		return
+	}
+	b = b[:0]
+	p.pool.Put(&b)
+}
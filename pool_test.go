@@ -0,0 +1,116 @@
+package zarr_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// gzipArray builds a fake bucket holding a size x size float32 array of
+// sequential values in gzip-compressed chunk x chunk chunks.
+func gzipArray(tb testing.TB, size, chunk int) (*fakeBucket, []float32) {
+	tb.Helper()
+
+	values := make([]float32, size*size)
+	for i := range values {
+		values[i] = float32(i)
+	}
+	objects := map[string][]byte{
+		".zarray": []byte(fmt.Sprintf(`{
+			"zarr_format": 2,
+			"shape": [%d, %d],
+			"chunks": [%d, %d],
+			"dtype": "<f4",
+			"compressor": {"id": "gzip", "level": 1},
+			"fill_value": 0.0,
+			"order": "C"
+		}`, size, size, chunk, chunk)),
+	}
+	for key, data := range chunkFloat32([]int{size, size}, []int{chunk, chunk}, values) {
+		raw, err := binary.Append(nil, binary.LittleEndian, data)
+		if err != nil {
+			tb.Fatalf("failed to encode chunk %s: %v", key, err)
+		}
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		w.Write(raw)
+		w.Close()
+		objects[key] = buf.Bytes()
+	}
+	return newFakeBucket(objects), values
+}
+
+func TestReader_PooledBuffersDoNotAlias(t *testing.T) {
+	fb, values := gzipArray(t, 16, 4)
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	chunk, err := reader.ReadChunk(ctx, []int{0, 0})
+	if err != nil {
+		t.Fatalf("ReadChunk failed: %v", err)
+	}
+	want := slices.Clone(chunk)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				row := (g + i) % 13
+				data, err := reader.ReadRegion(ctx, []int{row, 1}, []int{3, 14})
+				if err != nil {
+					t.Errorf("ReadRegion failed: %v", err)
+					return
+				}
+				got := decodeFloat32(data)
+				for r := 0; r < 3; r++ {
+					exp := values[(row+r)*16+1 : (row+r)*16+15]
+					if !slices.Equal(got[r*14:(r+1)*14], exp) {
+						t.Errorf("row %d of region at %d: expected %v, got %v", r, row, exp, got[r*14:(r+1)*14])
+						return
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// Buffers handed to the caller must never be recycled by later reads.
+	if !bytes.Equal(chunk, want) {
+		t.Error("a chunk returned by ReadChunk was overwritten by later reads")
+	}
+}
+
+func TestReader_TruncatedGzipChunk(t *testing.T) {
+	fb, _ := gzipArray(t, 16, 4)
+	full, _ := fb.get("0.0")
+	fb.put("0.0", full[:len(full)/2])
+	reader := openFake(t, fb)
+
+	if _, err := reader.ReadRegion(context.Background(), []int{0, 0}, []int{2, 2}); err == nil {
+		t.Error("expected an error for a truncated gzip chunk")
+	}
+}
+
+func BenchmarkReader_ConcurrentReadRegion(b *testing.B) {
+	fb, _ := gzipArray(b, 256, 64)
+	reader := openFake(b, fb)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := reader.ReadRegion(ctx, []int{16, 16}, []int{224, 224}); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
@@ -2,28 +2,160 @@ package zarr
 
 import (
 	"bytes"
-	"compress/zlib"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
 
-	"github.com/mrjoshuak/go-blosc"
-	"gocloud.dev/blob"
 	"gocloud.dev/gcerrors"
+	"golang.org/x/sync/singleflight"
 )
 
+// Reader reads a single Zarr V2 array from a blob bucket.
 type Reader struct {
-	bucket *blob.Bucket
+	store  *SharedBucket
+	ref    *bucketRef
+	prefix string
 	meta   *Metadata
+
+	// encoding maps chunk coordinates to keys, see ChunkEncoding.
+	encoding ChunkEncoding
+
+	// View state, see view.go.
+	isView  bool
+	perm    []int
+	flipped []bool
+	viewErr error
+
+	// decompressors overrides the built-in codecs by compressor id.
+	decompressors map[string]func([]byte) ([]byte, error)
+
+	// rawItemSize overrides the dtype item size, see WithRawDType.
+	rawItemSize int
+
+	// maxReadBytes bounds the output size of a single read, see
+	// WithMaxReadBytes.
+	maxReadBytes int64
+
+	// buffers recycles chunk buffers used internally by reads, see pool.go.
+	buffers *bufferPool
+
+	// fullReadThreshold is the coverage at which ReadRegionAuto reads the
+	// whole array, see WithFullReadThreshold.
+	fullReadThreshold float64
+
+	// fill holds one element of the decoded fill_value, or nil for zero.
+	fill []byte
+
+	// concurrency bounds parallel chunk reads, see ReaderOptions.
+	concurrency int
+
+	// transform is applied to float64 reads, see WithElementTransform.
+	transform func(float64) float64
+
+	// logger receives debug events, see WithLogger.
+	logger *slog.Logger
+
+	// maxChunks bounds the chunks touched by a single read, see
+	// WithMaxChunksPerRead.
+	maxChunks int
+
+	// chunkIndex caches chunk presence, see PrimeChunkIndex.
+	chunkIndex *chunkIndex
+
+	// attrs caches .zattrs for the reader and its views, see Attributes.
+	attrs *attrsCache
+
+	// cache holds decompressed chunks, see WithCache.
+	cache Cache
+
+	// retry controls retries of failed chunk fetches, see WithRetryPolicy.
+	retry RetryPolicy
+
+	// dedup caches whether the array holds dedup references, see
+	// EnableDedup.
+	dedup *dedupFlag
+
+	// flights joins concurrent downloads of the same chunk by the reader
+	// and its views, see sharedDownload.
+	flights *singleflight.Group
+
+	// format controls text output, see WithFormatOptions.
+	format FormatOptions
 }
 
+// NewReader opens the bucket at the given gocloud URL and reads the array
+// stored at its root.
 func NewReader(ctx context.Context, path string) (*Reader, error) {
-	bucket, err := blob.OpenBucket(ctx, path)
+	store, err := OpenSharedBucket(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := store.OpenArray(ctx, "")
+	// The reader holds its own reference; drop ours so that closing the
+	// reader closes the bucket.
+	store.Close()
+	if err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// NewReaderTimeout opens a reader like NewReader, giving up with
+// context.DeadlineExceeded if opening the bucket and loading .zarray take
+// longer than d. The timeout only covers the open; later reads are bounded
+// by the contexts passed to them.
+func NewReaderTimeout(ctx context.Context, path string, d time.Duration) (*Reader, error) {
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+	return NewReader(ctx, path)
+}
+
+// NewReaderFallback opens a reader like NewReader on the first of paths,
+// typically mirrors of the same array, that opens successfully, and reads
+// all chunks from that mirror. If none opens, the error lists why each one
+// failed.
+func NewReaderFallback(ctx context.Context, paths []string) (*Reader, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no paths to open")
+	}
+	errs := make([]error, 0, len(paths))
+	for _, path := range paths {
+		reader, err := NewReader(ctx, path)
+		if err == nil {
+			return reader, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", path, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, fmt.Errorf("failed to open any of %d paths: %w", len(paths), errors.Join(errs...))
+}
+
+// NewReaderWithDecompressors opens a reader like NewReader, using the given
+// functions to decode chunks whose compressor id is a key of decompressors.
+// The map is consulted before the built-in codecs, so it can both add codecs
+// and replace built-in ones for this reader only.
+func NewReaderWithDecompressors(ctx context.Context, path string, decompressors map[string]func([]byte) ([]byte, error)) (*Reader, error) {
+	reader, err := NewReader(ctx, path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create bucket: %w", err)
+		return nil, err
 	}
+	reader.decompressors = make(map[string]func([]byte) ([]byte, error), len(decompressors))
+	for id, fn := range decompressors {
+		reader.decompressors[id] = fn
+	}
+	return reader, nil
+}
 
-	reader, err := bucket.NewReader(ctx, ".zarray", nil)
+// loadMetadata reads the .zarray file of the array.
+func (r *Reader) loadMetadata(ctx context.Context) (*Metadata, error) {
+	reader, err := r.store.bucket.NewReader(ctx, r.key(".zarray"), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open .zarray: %w", err)
 	}
@@ -33,10 +165,48 @@ func NewReader(ctx context.Context, path string) (*Reader, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to load metadata: %w", err)
 	}
-	return &Reader{
-		bucket: bucket,
-		meta:   meta,
-	}, nil
+	return meta, nil
+}
+
+// itemSize returns the byte size of one array element.
+func (r *Reader) itemSize() (int, error) {
+	if r.rawItemSize > 0 {
+		return r.rawItemSize, nil
+	}
+	if r.meta.Structured != nil {
+		return r.meta.Structured.ItemSize, nil
+	}
+	_, size, err := ParseDType(r.meta.DType)
+	if err != nil {
+		return 0, fmt.Errorf("invalid dtype: %w", err)
+	}
+	return size, nil
+}
+
+// key returns the bucket key of an object belonging to the array.
+func (r *Reader) key(name string) string {
+	return r.prefix + name
+}
+
+// byteSize returns the number of bytes needed to hold an array of the given
+// shape, or ErrArrayTooLarge if that does not fit in an int on this platform.
+// The product is accumulated in int64 with explicit overflow checks so that
+// huge shapes fail cleanly instead of wrapping around.
+func byteSize(shape []int, itemSize int) (int, error) {
+	total := int64(itemSize)
+	for _, dim := range shape {
+		if dim < 0 {
+			return 0, fmt.Errorf("negative dimension %d in shape %v", dim, shape)
+		}
+		if dim != 0 && total > math.MaxInt64/int64(dim) {
+			return 0, fmt.Errorf("%w: shape %v with item size %d", ErrArrayTooLarge, shape, itemSize)
+		}
+		total *= int64(dim)
+	}
+	if total > math.MaxInt {
+		return 0, fmt.Errorf("%w: %d bytes for shape %v", ErrArrayTooLarge, total, shape)
+	}
+	return int(total), nil
 }
 
 // strides computes the C-order strides for a given shape.
@@ -53,124 +223,487 @@ func strides(shape []int) []int {
 	return s
 }
 
+// fortranStrides returns the column-major element strides of shape.
+func fortranStrides(shape []int) []int {
+	s := make([]int, len(shape))
+	stride := 1
+	for i := range shape {
+		s[i] = stride
+		stride *= shape[i]
+	}
+	return s
+}
+
+// chunkStrides returns the element strides within a decoded chunk, which
+// follow the array's order: row-major for "C", column-major for "F".
+func (r *Reader) chunkStrides() []int {
+	if r.meta.Order == "F" {
+		return fortranStrides(r.meta.Chunks)
+	}
+	return strides(r.meta.Chunks)
+}
+
 // ReadFull reads the entire Zarr array into a flat byte slice.
-func (r *Reader) ReadFull(ctx context.Context) ([]byte, error) {
-	// Parse dtype to get item size
-	_, itemSize, err := ParseDType(r.meta.DType)
+func (r *Reader) ReadFull(ctx context.Context, opts ...ReadOption) ([]byte, error) {
+	if r.viewErr != nil {
+		return nil, r.viewErr
+	}
+	totalBytes, err := r.fullBytes()
+	if err != nil {
+		return nil, err
+	}
+	buffer := make([]byte, totalBytes)
+	if err := r.readFullInto(ctx, buffer, newReadOptions(opts)); err != nil {
+		return nil, err
+	}
+	return buffer, nil
+}
+
+// ReadFullInto reads the entire array like ReadFull, but into dst instead
+// of a new slice, so that a pooled or memory-mapped buffer can be reused
+// across reads. dst must hold at least the array's bytes; only that prefix
+// is written.
+func (r *Reader) ReadFullInto(ctx context.Context, dst []byte, opts ...ReadOption) error {
+	if r.viewErr != nil {
+		return r.viewErr
+	}
+	totalBytes, err := r.fullBytes()
+	if err != nil {
+		return err
+	}
+	if len(dst) < totalBytes {
+		return fmt.Errorf("destination holds %d bytes, the array needs %d", len(dst), totalBytes)
+	}
+	return r.readFullInto(ctx, dst[:totalBytes], newReadOptions(opts))
+}
+
+// fullBytes returns the size in bytes of the whole array, checked against
+// the WithMaxReadBytes limit.
+func (r *Reader) fullBytes() (int, error) {
+	itemSize, err := r.itemSize()
 	if err != nil {
-		return nil, fmt.Errorf("invalid dtype: %w", err)
+		return 0, err
 	}
+	totalBytes, err := byteSize(r.meta.Shape, itemSize)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.checkReadSize(totalBytes); err != nil {
+		return 0, err
+	}
+	return totalBytes, nil
+}
 
-	totalElements := 1
-	for _, dim := range r.meta.Shape {
-		totalElements *= dim
+// readFullInto reads the entire array into buffer, which must hold exactly
+// the array's bytes.
+func (r *Reader) readFullInto(ctx context.Context, buffer []byte, o readOptions) error {
+	if r.hasViewTransform() {
+		return r.readRegionInto(ctx, buffer, make([]int, len(r.meta.Shape)), r.Shape(), nil, o)
 	}
 
-	totalBytes := totalElements * itemSize
-	buffer := make([]byte, totalBytes)
+	itemSize, err := r.itemSize()
+	if err != nil {
+		return err
+	}
 
-	// If 0D, read the single chunk "0" and return
+	// If 0D, read the single chunk "0"
 	if len(r.meta.Shape) == 0 {
-		reader, err := r.bucket.NewReader(ctx, "0", nil)
+		chunkData, err := r.readChunk(ctx, []int{}, o)
 		if err != nil {
-			if gcerrors.Code(err) == gcerrors.NotFound {
-				return buffer, nil // fill value (0)
-			}
-			return nil, fmt.Errorf("failed to open 0D chunk: %w", err)
+			return err
 		}
-		defer reader.Close()
-		_, err = io.ReadFull(reader, buffer)
-		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
-			return nil, fmt.Errorf("failed to read 0D chunk: %w", err)
-		}
-		return buffer, nil
+		copy(buffer, chunkData)
+		r.releaseChunk(chunkData)
+		return nil
 	}
 
 	grid := GridShape(r.meta.Shape, r.meta.Chunks)
+	if err := r.checkChunkCount(grid); err != nil {
+		return err
+	}
 	globalStrides := strides(r.meta.Shape)
-	chunkStrides := strides(r.meta.Chunks)
+	chunkStrides := r.chunkStrides()
+
+	last := make([]int, len(grid))
+	for i, n := range grid {
+		last[i] = n - 1
+	}
+	return r.visitChunks(ctx, make([]int, len(grid)), last, o.chunkOrder, func(ctx context.Context, coords []int) error {
+		return r.processChunk(ctx, coords, buffer, itemSize, globalStrides, chunkStrides, o)
+	})
+}
+
+// ReadChunk reads a single chunk from the Zarr array given its coordinates.
+// The elements are in the array's storage order, column-major for "F"
+// arrays, whereas ReadFull and ReadRegion always return C order.
+// The returned slice is owned by the caller and may be modified freely; it
+// never aliases buffers the reader reuses for later reads.
+func (r *Reader) ReadChunk(ctx context.Context, coords []int, opts ...ReadOption) ([]byte, error) {
+	if r.viewErr != nil {
+		return nil, r.viewErr
+	}
+	chunkBytes, err := r.chunkBytes()
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, chunkBytes)
+	n, err := r.ReadChunkInto(ctx, coords, buf, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// ReadChunkInto reads a single chunk like ReadChunk, but decodes it into dst
+// instead of a new slice, and returns the number of bytes written. dst must
+// hold at least a full chunk. A missing chunk fills that prefix of dst with
+// the fill value. Chunk buffers used along the way are recycled by the
+// reader, so a loop reusing one dst allocates little per chunk.
+func (r *Reader) ReadChunkInto(ctx context.Context, coords []int, dst []byte, opts ...ReadOption) (int, error) {
+	if r.viewErr != nil {
+		return 0, r.viewErr
+	}
+	chunkBytes, err := r.chunkBytes()
+	if err != nil {
+		return 0, err
+	}
+	if len(dst) < chunkBytes {
+		return 0, fmt.Errorf("destination holds %d bytes, a chunk needs %d", len(dst), chunkBytes)
+	}
+
+	chunkData, found, err := r.fetchChunk(ctx, coords, newReadOptions(opts))
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		r.fillChunk(dst[:chunkBytes])
+		return chunkBytes, nil
+	}
+	defer r.releaseChunk(chunkData)
+	return copy(dst, chunkData), nil
+}
 
-	// Recursive function to iterate over all chunk coordinates
-	var iterateChunks func(dim int, currentCoords []int) error
-	iterateChunks = func(dim int, currentCoords []int) error {
-		if dim == len(grid) {
-			return r.processChunk(ctx, currentCoords, buffer, itemSize, globalStrides, chunkStrides)
+// chunkBytes returns the size in bytes of a full chunk.
+func (r *Reader) chunkBytes() (int, error) {
+	itemSize, err := r.itemSize()
+	if err != nil {
+		return 0, err
+	}
+	return byteSize(r.meta.Chunks, itemSize)
+}
+
+func (r *Reader) readChunk(ctx context.Context, coords []int, o readOptions) ([]byte, error) {
+	chunkData, found, err := r.fetchChunk(ctx, coords, o)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		// Chunk missing, calculate expected size and return a chunk of the
+		// fill value
+		chunkBytes, err := r.chunkBytes()
+		if err != nil {
+			return nil, err
 		}
+		chunkData = r.buffers.get(chunkBytes)
+		r.fillChunk(chunkData)
+		return chunkData, nil
+	}
+	return chunkData, nil
+}
 
-		for i := 0; i < grid[dim]; i++ {
-			currentCoords[dim] = i
-			if err := iterateChunks(dim+1, currentCoords); err != nil {
-				return err
-			}
+// fetchChunk downloads and decompresses a chunk, or copies it from the
+// reader's cache. It reports found=false for chunks absent from the store,
+// unless the read policy makes that an error.
+func (r *Reader) fetchChunk(ctx context.Context, coords []int, o readOptions) ([]byte, bool, error) {
+	key := r.chunkKey(coords)
+	if r.knownAbsent(coords) {
+		return nil, false, r.missingChunk(ctx, key, o, true)
+	}
+
+	var chunkData []byte
+	var err error
+	if cached, ok := r.cacheGet(r.key(key)); ok {
+		r.debug(ctx, "chunk cache hit", slog.String("key", key))
+		chunkData = r.buffers.get(len(cached))
+		copy(chunkData, cached)
+	} else {
+		var found bool
+		chunkData, found, err = r.downloadChunk(ctx, key, o)
+		if err != nil || !found {
+			return nil, found, err
 		}
-		return nil
+		r.cacheAdd(r.key(key), chunkData)
 	}
 
-	coords := make([]int, len(grid))
-	if err := iterateChunks(0, coords); err != nil {
-		return nil, err
+	if chunkData, err = r.checkChunkSize(ctx, key, chunkData, o); err != nil {
+		return nil, false, err
 	}
+	if w := r.swapWidth(); w > 1 {
+		if !r.recyclable() {
+			// Leave memory handed back by a custom decoder untouched.
+			chunkData = bytes.Clone(chunkData)
+		}
+		SwapBytes(chunkData, w)
+	}
+	chunkData = r.padChunk(chunkData)
 
-	return buffer, nil
+	// Clip the capacity so that slicing a short chunk past its end panics
+	// instead of exposing stale bytes from a recycled buffer.
+	return chunkData[:len(chunkData):len(chunkData)], true, nil
 }
 
-// ReadChunk reads a single chunk from the Zarr array given its coordinates.
-func (r *Reader) ReadChunk(ctx context.Context, coords []int) ([]byte, error) {
-	key := ChunkKey(coords, ".")
-
-	reader, err := r.bucket.NewReader(ctx, key, nil)
+// downloadChunk fetches and decompresses the chunk stored under key, before
+// any byte swapping. It reports found=false for chunks absent from the store,
+// unless the read policy makes that an error.
+func (r *Reader) downloadChunk(ctx context.Context, key string, o readOptions) ([]byte, bool, error) {
+	chunkData, err := r.sharedDownload(ctx, key)
 	if err != nil {
 		if gcerrors.Code(err) == gcerrors.NotFound {
-			// Chunk missing, calculate expected size and return zero-filled array
-			_, itemSize, err := ParseDType(r.meta.DType)
-			if err != nil {
-				return nil, fmt.Errorf("invalid dtype: %w", err)
+			return nil, false, r.missingChunk(ctx, key, o, false)
+		}
+		return nil, false, err
+	}
+	return chunkData, true, nil
+}
+
+// sharedDownload runs fetchAndDecode for key, joining a download of the same
+// chunk already in flight on the reader or one of its views. A result handed
+// to several callers is copied for each, so that every caller owns the
+// buffer it gets back.
+func (r *Reader) sharedDownload(ctx context.Context, key string) ([]byte, error) {
+	if r.flights == nil {
+		return r.fetchAndDecode(ctx, key)
+	}
+	for attempt := 0; ; attempt++ {
+		v, err, shared := r.flights.Do(key, func() (any, error) {
+			return r.fetchAndDecode(ctx, key)
+		})
+		if err != nil {
+			if attempt == 0 && shared && ctx.Err() == nil &&
+				(errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+				// The caller that ran the download was cancelled; ours was
+				// not, so download again.
+				continue
 			}
-			expectedElements := 1
-			for _, dim := range r.meta.Chunks {
-				expectedElements *= dim
+			return nil, err
+		}
+		data := v.([]byte)
+		if shared {
+			own := r.buffers.get(len(data))
+			copy(own, data)
+			data = own
+		}
+		return data, nil
+	}
+}
+
+// fetchAndDecode fetches and decompresses the chunk stored under key.
+func (r *Reader) fetchAndDecode(ctx context.Context, key string) ([]byte, error) {
+	var raw []byte
+	err := r.withRetry(ctx, key, func() error {
+		var err error
+		raw, err = r.fetchObject(ctx, key)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.debug(ctx, "fetched chunk", slog.String("key", key), slog.Int("bytes", len(raw)))
+
+	var dst []byte
+	if r.recyclable() && decodesInto(r.meta.Compressor) {
+		// Size the output buffer for a full chunk; the codec grows it if the
+		// chunk turns out larger.
+		if itemSize, err := r.itemSize(); err == nil {
+			if n, err := byteSize(r.meta.Chunks, itemSize); err == nil {
+				dst = r.buffers.get(n)
 			}
-			return make([]byte, expectedElements*itemSize), nil
 		}
+	}
+	chunkData, err := r.decompress(ctx, key, raw, dst)
+	if r.meta.Compressor != nil && r.recyclable() && !sameBuffer(chunkData, raw) {
+		r.buffers.put(raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("chunk %s: %w", key, err)
+	}
+	return chunkData, nil
+}
+
+// fetchObject downloads the chunk stored under key in full, following a
+// reference entry of a deduplicated array to the chunk's contents.
+func (r *Reader) fetchObject(ctx context.Context, key string) ([]byte, error) {
+	raw, err := r.readObject(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return r.resolveRef(ctx, key, raw)
+}
+
+// readObject downloads the object stored under key, relative to the array,
+// in full.
+func (r *Reader) readObject(ctx context.Context, key string) ([]byte, error) {
+	reader, err := r.store.bucket.NewReader(ctx, r.key(key), nil)
+	if err != nil {
 		return nil, fmt.Errorf("failed to open chunk %s: %w", key, err)
 	}
 	defer reader.Close()
 
-	chunkData, err := io.ReadAll(reader)
+	var raw []byte
+	if size := reader.Size(); size >= 0 {
+		raw = r.buffers.get(int(size))
+		_, err = io.ReadFull(reader, raw)
+	} else {
+		raw, err = io.ReadAll(reader)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read chunk %s: %w", key, err)
 	}
+	return raw, nil
+}
 
-	if r.meta.Compressor != nil {
-		switch r.meta.Compressor.ID {
-		case "blosc":
-			chunkData, err = blosc.Decompress(chunkData)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decompress blosc chunk %s: %w", key, err)
-			}
-		case "zlib", "gzip":
-			zr, err := zlib.NewReader(bytes.NewReader(chunkData))
-			if err != nil {
-				return nil, fmt.Errorf("failed to init zlib reader for chunk %s: %w", key, err)
-			}
-			chunkData, err = io.ReadAll(zr)
-			zr.Close()
-			if err != nil {
-				return nil, fmt.Errorf("failed to decompress zlib chunk %s: %w", key, err)
-			}
-		default:
-			return nil, fmt.Errorf("unsupported compressor: %s", r.meta.Compressor.ID)
+// checkChunkSize applies the read's SizePolicy to a decoded chunk. It
+// returns an ErrChunkCorrupt error, or the chunk trimmed to a full chunk's
+// size if it is oversized, or to whole elements if it is short, in which
+// case padChunk completes it once it has been byte swapped.
+func (r *Reader) checkChunkSize(ctx context.Context, key string, data []byte, o readOptions) ([]byte, error) {
+	chunkBytes, err := r.chunkBytes()
+	if err != nil || len(data) == chunkBytes {
+		return data, nil
+	}
+	if o.size == SizeStrict {
+		return nil, fmt.Errorf("chunk %s: %w: decoded to %d bytes, expected %d", key, ErrChunkCorrupt, len(data), chunkBytes)
+	}
+	if r.logger != nil {
+		msg := "oversized chunk truncated"
+		if len(data) < chunkBytes {
+			msg = "short chunk padded with fill value"
 		}
+		r.logger.LogAttrs(ctx, slog.LevelWarn, msg,
+			slog.String("key", key),
+			slog.Int("bytes", len(data)),
+			slog.Int("expected", chunkBytes))
+	}
+	if len(data) > chunkBytes {
+		return data[:chunkBytes], nil
 	}
+	itemSize, _ := r.itemSize()
+	return data[:len(data)-len(data)%itemSize], nil
+}
 
-	return chunkData, nil
+// padChunk extends a short chunk to a full chunk's size with the fill
+// value. Chunks of dtypes without a fixed item size are returned as is.
+func (r *Reader) padChunk(data []byte) []byte {
+	chunkBytes, err := r.chunkBytes()
+	if err != nil || len(data) >= chunkBytes {
+		return data
+	}
+	padded := r.buffers.get(chunkBytes)
+	copy(padded, data)
+	r.fillChunk(padded[len(data):])
+	r.releaseChunk(data)
+	return padded
+}
+
+// recyclable reports whether chunk buffers produced by fetchChunk belong to
+// the reader and may be returned to its pool once a read is done with them.
+// Output of decompressors registered by the user is never recycled, since
+// they may hand back memory they keep using.
+func (r *Reader) recyclable() bool {
+	if cfg := r.meta.Compressor; cfg != nil {
+		if _, ok := r.decompressors[cfg.ID]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// releaseChunk returns a chunk buffer obtained from readChunk or
+// readChunkSpan to the pool. It must only be called by internal read paths
+// that do not hand the buffer to the caller.
+func (r *Reader) releaseChunk(data []byte) {
+	if r.recyclable() {
+		r.buffers.put(data)
+	}
+}
+
+// sameBuffer reports whether a and b share the same backing array start.
+func sameBuffer(a, b []byte) bool {
+	return cap(a) > 0 && cap(b) > 0 && &a[:1][0] == &b[:1][0]
+}
+
+// missingChunk applies the read's NotFoundPolicy to an absent chunk. It
+// returns nil when the chunk should be filled. indexed tells whether the
+// chunk index, rather than the bucket, reported the chunk missing.
+func (r *Reader) missingChunk(ctx context.Context, key string, o readOptions, indexed bool) error {
+	if o.notFound == NotFoundError {
+		r.debug(ctx, "chunk not found", slog.String("key", key), slog.Bool("indexed", indexed))
+		return fmt.Errorf("chunk %s: %w", key, ErrChunkNotFound)
+	}
+	r.debug(ctx, "chunk not found, using fill value", slog.String("key", key), slog.Bool("indexed", indexed))
+	return nil
+}
+
+// readChunkSpan fetches length bytes starting at offset from an uncompressed
+// chunk without downloading the rest of it. Bytes past the end of a short
+// chunk object, and the whole span of a missing chunk, hold the fill value.
+// offset must be a multiple of the item size.
+func (r *Reader) readChunkSpan(ctx context.Context, coords []int, offset, length int, o readOptions) ([]byte, error) {
+	key := r.chunkKey(coords)
+	span := r.buffers.get(length)
+	r.fillChunk(span)
+	if r.knownAbsent(coords) {
+		if err := r.missingChunk(ctx, key, o, true); err != nil {
+			return nil, err
+		}
+		return span, nil
+	}
+
+	var n int
+	err := r.withRetry(ctx, key, func() error {
+		reader, err := r.store.bucket.NewRangeReader(ctx, r.key(key), int64(offset), int64(length), nil)
+		if err != nil {
+			return fmt.Errorf("failed to open chunk %s: %w", key, err)
+		}
+		defer reader.Close()
+
+		// Refill the span so that a retry reading fewer bytes than a
+		// failed attempt leaves no stale data behind.
+		r.fillChunk(span)
+		n, err = io.ReadFull(reader, span)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return fmt.Errorf("failed to read chunk %s: %w", key, err)
+		}
+		return nil
+	})
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			if err := r.missingChunk(ctx, key, o, false); err != nil {
+				return nil, err
+			}
+			return span, nil
+		}
+		return nil, err
+	}
+	// Swap only the whole elements that were read; the rest of the span,
+	// including a trailing partial element, holds the fill value in
+	// little-endian order.
+	if itemSize, err := r.itemSize(); err == nil && n%itemSize != 0 {
+		n -= n % itemSize
+		r.fillChunk(span[n:])
+	}
+	SwapBytes(span[:n], r.swapWidth())
+	r.debug(ctx, "fetched chunk range", slog.String("key", key), slog.Int("offset", offset), slog.Int("bytes", length))
+	return span, nil
 }
 
-func (r *Reader) processChunk(ctx context.Context, chunkCoords []int, globalBuffer []byte, itemSize int, globalStrides, chunkStrides []int) error {
-	chunkData, err := r.ReadChunk(ctx, chunkCoords)
+func (r *Reader) processChunk(ctx context.Context, chunkCoords []int, globalBuffer []byte, itemSize int, globalStrides, chunkStrides []int, o readOptions) error {
+	chunkData, err := r.readChunk(ctx, chunkCoords, o)
 	if err != nil {
 		return err
 	}
+	defer r.releaseChunk(chunkData)
 
 	// Calculate bounds for this chunk within the global array
 	chunkStartGlobal := make([]int, len(r.meta.Shape))
@@ -222,100 +755,281 @@ func (r *Reader) processChunk(ctx context.Context, chunkCoords []int, globalBuff
 }
 
 // ReadRegion reads an N-dimensional region of the Zarr array.
-func (r *Reader) ReadRegion(ctx context.Context, start, shape []int) ([]byte, error) {
-	if len(start) != len(r.meta.Shape) || len(shape) != len(r.meta.Shape) {
+func (r *Reader) ReadRegion(ctx context.Context, start, shape []int, opts ...ReadOption) ([]byte, error) {
+	if r.viewErr != nil {
+		return nil, r.viewErr
+	}
+	viewShape := r.Shape()
+	if len(start) != len(viewShape) || len(shape) != len(viewShape) {
 		return nil, fmt.Errorf("start and shape must match array dimensionality")
 	}
 
 	// Validate bounds
-	for i := range r.meta.Shape {
-		if start[i] < 0 || shape[i] <= 0 || start[i]+shape[i] > r.meta.Shape[i] {
+	for i := range viewShape {
+		if start[i] < 0 || shape[i] <= 0 || start[i]+shape[i] > viewShape[i] {
+			return nil, fmt.Errorf("region out of bounds at dimension %d", i)
+		}
+	}
+	return r.readRegion(ctx, start, shape, nil, newReadOptions(opts))
+}
+
+// ReadRegionStrided reads every step[i]-th element from start[i] up to, but
+// excluding, stop[i] along each dimension, like data[start:stop:step] in
+// numpy, and returns them in C order. The result has
+// ceil((stop[i]-start[i])/step[i]) elements along dimension i. Chunks that
+// hold none of the selected elements are not fetched.
+func (r *Reader) ReadRegionStrided(ctx context.Context, start, stop, step []int, opts ...ReadOption) ([]byte, error) {
+	if r.viewErr != nil {
+		return nil, r.viewErr
+	}
+	viewShape := r.Shape()
+	if len(start) != len(viewShape) || len(stop) != len(viewShape) || len(step) != len(viewShape) {
+		return nil, fmt.Errorf("start, stop and step must match array dimensionality")
+	}
+	count := make([]int, len(viewShape))
+	for i := range viewShape {
+		if step[i] <= 0 {
+			return nil, fmt.Errorf("step must be positive, got %d at dimension %d", step[i], i)
+		}
+		if start[i] < 0 || start[i] >= stop[i] || stop[i] > viewShape[i] {
 			return nil, fmt.Errorf("region out of bounds at dimension %d", i)
 		}
+		count[i] = (stop[i] - start[i] + step[i] - 1) / step[i]
 	}
+	return r.readRegion(ctx, start, count, step, newReadOptions(opts))
+}
 
-	// Calculate item size
-	_, itemSize, err := ParseDType(r.meta.DType)
+// readRegion reads count[i] elements spaced step[i] apart from start[i]
+// along each view dimension. A nil step reads a contiguous region.
+func (r *Reader) readRegion(ctx context.Context, start, count, step []int, o readOptions) ([]byte, error) {
+	itemSize, err := r.itemSize()
+	if err != nil {
+		return nil, err
+	}
+	totalBytes, err := byteSize(count, itemSize)
 	if err != nil {
-		return nil, fmt.Errorf("invalid dtype: %w", err)
+		return nil, err
 	}
+	if err := r.checkReadSize(totalBytes); err != nil {
+		return nil, err
+	}
+	out := make([]byte, totalBytes)
+	if err := r.readRegionInto(ctx, out, start, count, step, o); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-	totalElements := 1
-	for _, dim := range shape {
-		totalElements *= dim
+// readRegionInto reads a region like readRegion into out, which must hold
+// exactly the region's bytes.
+func (r *Reader) readRegionInto(ctx context.Context, out []byte, start, count, step []int, o readOptions) error {
+	shape := count
+	if step == nil {
+		step = make([]int, len(start))
+		for i := range step {
+			step[i] = 1
+		}
+	}
+
+	itemSize, err := r.itemSize()
+	if err != nil {
+		return err
 	}
-	out := make([]byte, totalElements*itemSize)
 
 	if len(r.meta.Shape) == 0 {
-		return r.ReadChunk(ctx, []int{})
+		chunkData, err := r.readChunk(ctx, []int{}, o)
+		if err != nil {
+			return err
+		}
+		copy(out, chunkData)
+		r.releaseChunk(chunkData)
+		return nil
+	}
+
+	// Translate the requested region to storage coordinates. A transposed
+	// axis takes the destination stride of the view axis it is presented as,
+	// and flipped axes are written back to front by negating that stride.
+	// Along a flipped axis the selected elements start from the far end.
+	viewStrides := strides(shape)
+	storageStart := make([]int, len(start))
+	storageShape := make([]int, len(shape))
+	storageStep := make([]int, len(shape))
+	dstStrides := make([]int, len(shape))
+	for j := range start {
+		i := r.storageAxis(j)
+		storageStart[i] = start[j]
+		storageShape[i] = shape[j]
+		storageStep[i] = step[j]
+		dstStrides[i] = viewStrides[j]
+		if r.isFlipped(i) {
+			storageStart[i] = r.meta.Shape[i] - 1 - start[j] - (shape[j]-1)*step[j]
+			dstStrides[i] = -dstStrides[i]
+		}
 	}
 
 	minChunk := make([]int, len(start))
 	maxChunk := make([]int, len(start))
+	perAxis := make([]int, len(start))
 	for i := range start {
-		minChunk[i] = start[i] / r.meta.Chunks[i]
-		maxChunk[i] = (start[i] + shape[i] - 1) / r.meta.Chunks[i]
+		minChunk[i] = storageStart[i] / r.meta.Chunks[i]
+		maxChunk[i] = (storageStart[i] + (storageShape[i]-1)*storageStep[i]) / r.meta.Chunks[i]
+		perAxis[i] = maxChunk[i] - minChunk[i] + 1
+	}
+	if err := r.checkChunkCount(perAxis); err != nil {
+		return err
 	}
 
-	dstStrides := strides(shape)
-	chunkStrides := strides(r.meta.Chunks)
-
-	var iterateChunks func(dim int, currentChunkCoords []int) error
-	iterateChunks = func(dim int, currentChunkCoords []int) error {
-		if dim == len(minChunk) {
-			chunkData, err := r.ReadChunk(ctx, currentChunkCoords)
-			if err != nil {
-				return err
-			}
+	chunkStrides := r.chunkStrides()
+	chunkBytes, err := byteSize(r.meta.Chunks, itemSize)
+	if err != nil {
+		return err
+	}
+	chunkElements := chunkBytes / itemSize
+	// Byte ranges of a reference entry mean nothing, so deduplicated
+	// arrays always read whole chunks.
+	deduped, err := r.deduplicated(ctx)
+	if err != nil {
+		return err
+	}
+	// Stepping through a chunk skips step-1 elements between selected ones.
+	srcStrides := make([]int, len(chunkStrides))
+	for i := range srcStrides {
+		srcStrides[i] = chunkStrides[i] * storageStep[i]
+	}
 
-			copyShape := make([]int, len(r.meta.Shape))
-			srcOffset := make([]int, len(r.meta.Shape))
-			dstOffset := make([]int, len(r.meta.Shape))
+	visitChunk := func(ctx context.Context, currentChunkCoords []int) error {
+		copyShape := make([]int, len(r.meta.Shape))
+		dstOffset := make([]int, len(r.meta.Shape))
+		// first is the chunk-relative index of the first selected element.
+		first := 0
 
-			for i := range r.meta.Shape {
-				chunkStartGlobal := currentChunkCoords[i] * r.meta.Chunks[i]
-				chunkEndGlobal := chunkStartGlobal + r.meta.Chunks[i]
-				if chunkEndGlobal > r.meta.Shape[i] {
-					chunkEndGlobal = r.meta.Shape[i]
-				}
+		for i := range r.meta.Shape {
+			chunkStartGlobal := currentChunkCoords[i] * r.meta.Chunks[i]
+			chunkEndGlobal := chunkStartGlobal + r.meta.Chunks[i]
+			if chunkEndGlobal > r.meta.Shape[i] {
+				chunkEndGlobal = r.meta.Shape[i]
+			}
 
-				reqStartGlobal := start[i]
-				reqEndGlobal := start[i] + shape[i]
+			// Selected elements storageStart + k*step, for k in [lo, hi),
+			// fall inside this chunk.
+			s := storageStep[i]
+			lo := max(0, ceilDiv(chunkStartGlobal-storageStart[i], s))
+			hi := min(storageShape[i], ceilDiv(chunkEndGlobal-storageStart[i], s))
+			if lo >= hi {
+				return nil
+			}
 
-				intersectStart := max(chunkStartGlobal, reqStartGlobal)
-				intersectEnd := min(chunkEndGlobal, reqEndGlobal)
+			copyShape[i] = hi - lo
+			first += (storageStart[i] + lo*s - chunkStartGlobal) * chunkStrides[i]
+			dstOffset[i] = lo
+			if r.isFlipped(i) {
+				// With a negative stride, offset -k lands on index k.
+				dstOffset[i] = -(storageShape[i] - 1 - dstOffset[i])
+			}
+		}
+		noOffset := make([]int, len(copyShape))
 
-				if intersectStart >= intersectEnd {
-					return nil
+		// Uncompressed chunks are laid out as-is in storage, so only the
+		// byte span covering the intersection needs to be fetched, unless
+		// the whole chunk is worth caching.
+		if r.meta.Compressor == nil && r.cache == nil && !deduped {
+			last := first
+			for i := range copyShape {
+				last += (copyShape[i] - 1) * srcStrides[i]
+			}
+			if span := last - first + 1; span < chunkElements {
+				spanData, err := r.readChunkSpan(ctx, currentChunkCoords, first*itemSize, span*itemSize, o)
+				if err != nil {
+					return err
 				}
-
-				copyShape[i] = intersectEnd - intersectStart
-				srcOffset[i] = intersectStart - chunkStartGlobal
-				dstOffset[i] = intersectStart - reqStartGlobal
+				copyND(out, dstStrides, dstOffset, spanData, srcStrides, noOffset, copyShape, itemSize)
+				r.releaseChunk(spanData)
+				return nil
 			}
-
-			copyND(out, dstStrides, dstOffset, chunkData, chunkStrides, srcOffset, copyShape, itemSize)
-			return nil
 		}
 
-		for i := minChunk[dim]; i <= maxChunk[dim]; i++ {
-			currentChunkCoords[dim] = i
-			if err := iterateChunks(dim+1, currentChunkCoords); err != nil {
-				return err
-			}
+		chunkData, err := r.readChunk(ctx, currentChunkCoords, o)
+		if err != nil {
+			return err
 		}
+		copyND(out, dstStrides, dstOffset, chunkData[first*itemSize:], srcStrides, noOffset, copyShape, itemSize)
+		r.releaseChunk(chunkData)
 		return nil
 	}
 
-	coords := make([]int, len(minChunk))
-	if err := iterateChunks(0, coords); err != nil {
-		return nil, err
+	return r.visitChunks(ctx, minChunk, maxChunk, o.chunkOrder, visitChunk)
+}
+
+// ceilDiv returns a/b rounded towards positive infinity, for b > 0.
+func ceilDiv(a, b int) int {
+	q := a / b
+	if a%b > 0 {
+		q++
+	}
+	return q
+}
+
+// ReadRegionPartial reads a region like ReadRegion, but start and shape may
+// cover only the leading dimensions of the array; the remaining dimensions
+// are read in full. For a 2D array, start [1] and shape [2] read rows 1 and 2
+// at full width.
+func (r *Reader) ReadRegionPartial(ctx context.Context, start, shape []int, opts ...ReadOption) ([]byte, error) {
+	if r.viewErr != nil {
+		return nil, r.viewErr
+	}
+	viewShape := r.Shape()
+	if len(start) != len(shape) {
+		return nil, fmt.Errorf("start has %d dimensions but shape has %d", len(start), len(shape))
+	}
+	if len(start) > len(viewShape) {
+		return nil, fmt.Errorf("region has %d dimensions but the array has %d", len(start), len(viewShape))
 	}
 
-	return out, nil
+	fullStart := make([]int, len(viewShape))
+	fullShape := append([]int(nil), viewShape...)
+	copy(fullStart, start)
+	copy(fullShape, shape)
+	return r.ReadRegion(ctx, fullStart, fullShape, opts...)
+}
+
+// ChunksForRegion returns, in C order, the coordinates of the chunks that
+// the region of the given start and shape intersects: the chunks ReadRegion
+// reads for it. The region is given in view coordinates, while the returned
+// coordinates address stored chunks, as taken by ReadChunk.
+func (r *Reader) ChunksForRegion(start, shape []int) ([][]int, error) {
+	if r.viewErr != nil {
+		return nil, r.viewErr
+	}
+	viewShape := r.Shape()
+	if len(start) != len(viewShape) || len(shape) != len(viewShape) {
+		return nil, fmt.Errorf("start and shape must match array dimensionality")
+	}
+	first := make([]int, len(start))
+	last := make([]int, len(start))
+	for j := range viewShape {
+		if start[j] < 0 || shape[j] <= 0 || start[j]+shape[j] > viewShape[j] {
+			return nil, fmt.Errorf("region out of bounds at dimension %d", j)
+		}
+		i := r.storageAxis(j)
+		lo := start[j]
+		if r.isFlipped(i) {
+			lo = r.meta.Shape[i] - start[j] - shape[j]
+		}
+		first[i] = lo / r.meta.Chunks[i]
+		last[i] = (lo + shape[j] - 1) / r.meta.Chunks[i]
+	}
+
+	var chunks [][]int
+	forEachChunk(first, last, ChunkOrderC, func(coords []int) error {
+		chunks = append(chunks, slices.Clone(coords))
+		return nil
+	})
+	return chunks, nil
 }
 
 // copyND recursively copies n-dimensional data from src to dst.
+// Destination strides may be negative to write an axis in reverse order, in
+// which case the matching offset must be negative too.
 func copyND(
 	dst []byte, dstStrides, dstOffset []int,
 	src []byte, srcStrides, srcOffset []int,
@@ -362,12 +1076,16 @@ func copyND(
 	iterate(0, startSrcIdx, startDstIdx)
 }
 
-
+// Metadata returns the parsed .zarray metadata of the array.
 func (r *Reader) Metadata() *Metadata {
 	return r.meta
 }
 
-// Close closes the reader.
+// Close closes the reader. The underlying bucket is closed once no other
+// Reader shares it.
 func (r *Reader) Close() error {
-	return r.bucket.Close()
+	if r.isView {
+		return nil
+	}
+	return r.ref.release()
 }
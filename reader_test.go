@@ -1,14 +1,19 @@
 package zarr_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
 	"io"
 	"math"
 	"net/http"
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -339,3 +344,571 @@ func downloadFile(t *testing.T, url string, destPath string) {
 		t.Fatalf("Failed to write to file %s: %v", destPath, err)
 	}
 }
+
+func TestReader_ReadRegionRangeRead(t *testing.T) {
+	// A single 64x64 uncompressed chunk holding the values 0..4095.
+	values := make([]float32, 64*64)
+	for i := range values {
+		values[i] = float32(i)
+	}
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [64, 64],
+			"chunks": [64, 64],
+			"dtype": "<f4",
+			"compressor": null,
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+		"0.0": encodeLE(t, values),
+	})
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	data, err := reader.ReadRegion(ctx, []int{10, 5}, []int{3, 4})
+	if err != nil {
+		t.Fatalf("ReadRegion failed: %v", err)
+	}
+	got := decodeFloat32(data)
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 4; c++ {
+			want := float32((10+r)*64 + 5 + c)
+			if got[r*4+c] != want {
+				t.Fatalf("mismatch at (%d, %d): expected %v, got %v", r, c, want, got[r*4+c])
+			}
+		}
+	}
+
+	// Only the span from element (10, 5) to element (12, 8) is fetched.
+	reads := fb.readsOf("0.0")
+	if len(reads) != 1 {
+		t.Fatalf("expected exactly one read of the chunk, got %v", reads)
+	}
+	wantOffset := int64((10*64 + 5) * 4)
+	wantLength := int64((2*64 + 3 + 1) * 4)
+	if reads[0].Offset != wantOffset || reads[0].Length != wantLength {
+		t.Errorf("expected range [%d, +%d), got [%d, +%d)", wantOffset, wantLength, reads[0].Offset, reads[0].Length)
+	}
+}
+
+func TestReader_ArrayTooLarge(t *testing.T) {
+	// 2^40 x 2^40 float32 elements need 2^82 bytes, which overflows int64.
+	dir := t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [1099511627776, 1099511627776],
+		"chunks": [1, 1],
+		"dtype": "<f4",
+		"compressor": null,
+		"fill_value": 0.0,
+		"order": "C"
+	}`, nil)
+	reader := openReader(t, dir)
+	ctx := context.Background()
+
+	if _, err := reader.ReadFull(ctx); !errors.Is(err, zarr.ErrArrayTooLarge) {
+		t.Errorf("ReadFull: expected ErrArrayTooLarge, got %v", err)
+	}
+	if _, err := reader.ReadRegion(ctx, []int{0, 0}, []int{1 << 40, 1 << 40}); !errors.Is(err, zarr.ErrArrayTooLarge) {
+		t.Errorf("ReadRegion: expected ErrArrayTooLarge, got %v", err)
+	}
+
+	// Small regions of the huge array remain readable.
+	data, err := reader.ReadRegion(ctx, []int{5, 5}, []int{2, 2})
+	if err != nil {
+		t.Fatalf("ReadRegion of a small region failed: %v", err)
+	}
+	if len(data) != 16 {
+		t.Errorf("expected 16 bytes, got %d", len(data))
+	}
+}
+
+func TestReader_ReadChunkReturnsCopy(t *testing.T) {
+	fb, _ := gzipArray(t, 16, 4)
+	meta, _ := fb.get(".zarray")
+	fb.put(".zarray", bytes.Replace(meta, []byte(`"shape": [16, 16]`), []byte(`"shape": [20, 16]`), 1))
+	reader := openFake(t, fb)
+	ctx := context.Background()
+	lazy := reader.Lazy(ctx)
+
+	// 1.1 is stored, 4.0 is missing and filled.
+	for _, coords := range [][]int{{1, 1}, {4, 0}} {
+		first, err := reader.ReadChunk(ctx, coords)
+		if err != nil {
+			t.Fatalf("ReadChunk(%v) failed: %v", coords, err)
+		}
+		want := bytes.Clone(first)
+		before, err := lazy.At(coords[0]*4, coords[1]*4)
+		if err != nil {
+			t.Fatalf("At failed: %v", err)
+		}
+
+		for i := range first {
+			first[i] = 0xff
+		}
+
+		again, err := reader.ReadChunk(ctx, coords)
+		if err != nil {
+			t.Fatalf("second ReadChunk(%v) failed: %v", coords, err)
+		}
+		if !bytes.Equal(again, want) {
+			t.Errorf("chunk %v changed after mutating an earlier result", coords)
+		}
+		region, err := reader.ReadRegion(ctx, []int{coords[0] * 4, coords[1] * 4}, []int{4, 4})
+		if err != nil {
+			t.Fatalf("ReadRegion failed: %v", err)
+		}
+		if !bytes.Equal(region, want) {
+			t.Errorf("region over chunk %v changed after mutating a ReadChunk result", coords)
+		}
+		if after, _ := lazy.At(coords[0]*4, coords[1]*4); after != before {
+			t.Errorf("lazy element at chunk %v changed from %v to %v", coords, before, after)
+		}
+	}
+}
+
+func TestReader_ReadChunkInto(t *testing.T) {
+	fb, _ := gzipArray(t, 16, 4)
+	meta, _ := fb.get(".zarray")
+	fb.put(".zarray", bytes.Replace(meta, []byte(`"fill_value": 0.0`), []byte(`"fill_value": 7.0`), 1))
+	fb.Delete(context.Background(), "2.2")
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	dst := make([]byte, 4*4*4+3)
+	for i := range dst {
+		dst[i] = 0xee
+	}
+	for _, coords := range [][]int{{1, 2}, {2, 2}} {
+		n, err := reader.ReadChunkInto(ctx, coords, dst)
+		if err != nil {
+			t.Fatalf("ReadChunkInto(%v) failed: %v", coords, err)
+		}
+		if n != 64 {
+			t.Errorf("ReadChunkInto(%v) wrote %d bytes, expected 64", coords, n)
+		}
+		want, err := reader.ReadChunk(ctx, coords)
+		if err != nil {
+			t.Fatalf("ReadChunk(%v) failed: %v", coords, err)
+		}
+		if !bytes.Equal(dst[:n], want) {
+			t.Errorf("ReadChunkInto(%v) and ReadChunk disagree", coords)
+		}
+		if !bytes.Equal(dst[n:], []byte{0xee, 0xee, 0xee}) {
+			t.Errorf("ReadChunkInto(%v) wrote past the chunk: % x", coords, dst[n:])
+		}
+	}
+	if got := decodeFloat32(dst[:64]); got[0] != 7 || got[15] != 7 {
+		t.Errorf("expected the missing chunk to hold the fill value, got %v", got)
+	}
+
+	if _, err := reader.ReadChunkInto(ctx, []int{0, 0}, make([]byte, 63)); err == nil {
+		t.Error("expected a destination shorter than a chunk to be rejected")
+	}
+}
+
+func TestReader_ReadFullInto(t *testing.T) {
+	fb, _ := gzipArray(t, 8, 3)
+	fb.Delete(context.Background(), "1.1")
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	for name, r := range map[string]*zarr.Reader{
+		"plain":      reader,
+		"transposed": reader.Transpose([]int{1, 0}),
+	} {
+		want, err := r.ReadFull(ctx)
+		if err != nil {
+			t.Fatalf("%s: ReadFull failed: %v", name, err)
+		}
+		dst := make([]byte, len(want)+2)
+		for i := range dst {
+			dst[i] = 0xee
+		}
+		// Read twice to check that reusing a dirty buffer is fine.
+		for i := 0; i < 2; i++ {
+			if err := r.ReadFullInto(ctx, dst); err != nil {
+				t.Fatalf("%s: ReadFullInto failed: %v", name, err)
+			}
+			if !bytes.Equal(dst[:len(want)], want) {
+				t.Errorf("%s: ReadFullInto and ReadFull disagree", name)
+			}
+		}
+		if !bytes.Equal(dst[len(want):], []byte{0xee, 0xee}) {
+			t.Errorf("%s: ReadFullInto wrote past the array: % x", name, dst[len(want):])
+		}
+	}
+
+	if err := reader.ReadFullInto(ctx, make([]byte, 8*8*4-1)); err == nil {
+		t.Error("expected a destination shorter than the array to be rejected")
+	}
+	if err := reader.WithMaxReadBytes(16).ReadFullInto(ctx, make([]byte, 8*8*4)); err == nil {
+		t.Error("expected the read size limit to apply")
+	}
+}
+
+func BenchmarkReader_ReadChunkInto(b *testing.B) {
+	fb, _ := gzipArray(b, 256, 64)
+	reader := openFake(b, fb)
+	ctx := context.Background()
+	coords := []int{1, 2}
+
+	b.Run("ReadChunk", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := reader.ReadChunk(ctx, coords); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("ReadChunkInto", func(b *testing.B) {
+		dst := make([]byte, 64*64*4)
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := reader.ReadChunkInto(ctx, coords, dst); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestReader_ChunkEncoding(t *testing.T) {
+	values := []float32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	chunks := chunkFloat32([]int{4, 4}, []int{2, 2}, values)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// zarray is the dimension_separator entry, if any.
+		zarray string
+		enc    *zarr.ChunkEncoding
+		key    func(coords string) string
+	}{
+		{"nested", `"dimension_separator": "/",`, nil, func(c string) string { return strings.ReplaceAll(c, ".", "/") }},
+		{"v3 style", ``, &zarr.ChunkEncoding{Prefix: "c/", Separator: "/"}, func(c string) string { return "c/" + strings.ReplaceAll(c, ".", "/") }},
+		{"reversed axes", ``, &zarr.ChunkEncoding{ReverseAxes: true}, func(c string) string {
+			parts := strings.Split(c, ".")
+			return parts[1] + "." + parts[0]
+		}},
+		{"padded", ``, &zarr.ChunkEncoding{ZeroPadWidth: 4}, func(c string) string {
+			parts := strings.Split(c, ".")
+			return "000" + parts[0] + ".000" + parts[1]
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fb := newFakeBucket(map[string][]byte{
+				".zarray": []byte(`{
+					"zarr_format": 2,
+					"shape": [4, 4],
+					"chunks": [2, 2],
+					"dtype": "<f4",
+					"compressor": null,
+					"fill_value": 0.0,
+					` + tt.zarray + `
+					"order": "C"
+				}`),
+			})
+			for coords, chunk := range chunks {
+				if coords != "1.1" {
+					fb.put(tt.key(coords), encodeLE(t, chunk))
+				}
+			}
+			reader := openFake(t, fb)
+			if tt.enc != nil {
+				reader = reader.WithChunkEncoding(*tt.enc)
+			}
+
+			data, err := reader.ReadFull(ctx)
+			if err != nil {
+				t.Fatalf("ReadFull failed: %v", err)
+			}
+			want := slices.Clone(values)
+			for _, i := range []int{10, 11, 14, 15} {
+				want[i] = 0
+			}
+			if got := decodeFloat32(data); !slices.Equal(got, want) {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+
+			entries, err := reader.ChunkManifest(ctx)
+			if err != nil {
+				t.Fatalf("ChunkManifest failed: %v", err)
+			}
+			if len(entries) != 3 || entries[2].Key != tt.key("1.0") {
+				t.Errorf("unexpected manifest: %+v", entries)
+			}
+		})
+	}
+}
+
+func TestReader_FortranOrder(t *testing.T) {
+	zarray := func(chunks string) string {
+		return `{
+			"zarr_format": 2,
+			"shape": [4, 4],
+			"chunks": ` + chunks + `,
+			"dtype": "<f4",
+			"compressor": null,
+			"fill_value": 0.0,
+			"order": "F"
+		}`
+	}
+	// Element (i, j) holds 4*i + j; chunks store their columns one after
+	// another.
+	single := t.TempDir()
+	writeFloat32Array(t, single, zarray("[4, 4]"), map[string][]float32{
+		"0.0": {0, 4, 8, 12, 1, 5, 9, 13, 2, 6, 10, 14, 3, 7, 11, 15},
+	})
+	tiled := t.TempDir()
+	writeFloat32Array(t, tiled, zarray("[2, 2]"), map[string][]float32{
+		"0.0": {0, 4, 1, 5},
+		"0.1": {2, 6, 3, 7},
+		"1.0": {8, 12, 9, 13},
+		"1.1": {10, 14, 11, 15},
+	})
+
+	for name, dir := range map[string]string{"single chunk": single, "2x2 chunks": tiled} {
+		t.Run(name, func(t *testing.T) {
+			reader := openReader(t, dir)
+			ctx := context.Background()
+
+			full, err := reader.ReadFull(ctx)
+			if err != nil {
+				t.Fatalf("ReadFull failed: %v", err)
+			}
+			for i, v := range decodeFloat32(full) {
+				if v != float32(i) {
+					t.Fatalf("ReadFull: expected C-order values 0..15, got %v", decodeFloat32(full))
+				}
+			}
+
+			region, err := reader.ReadRegion(ctx, []int{1, 1}, []int{2, 3})
+			if err != nil {
+				t.Fatalf("ReadRegion failed: %v", err)
+			}
+			want := []float32{5, 6, 7, 9, 10, 11}
+			if got := decodeFloat32(region); !slices.Equal(got, want) {
+				t.Errorf("ReadRegion: expected %v, got %v", want, got)
+			}
+
+			v, err := reader.Lazy(ctx).At(2, 1)
+			if err != nil {
+				t.Fatalf("At failed: %v", err)
+			}
+			if v != 9 {
+				t.Errorf("At(2, 1): expected 9, got %v", v)
+			}
+		})
+	}
+}
+
+func TestReader_ReadRegionPartial(t *testing.T) {
+	reader := openSequential4x4(t)
+	ctx := context.Background()
+
+	data, err := reader.ReadRegionPartial(ctx, []int{1}, []int{2})
+	if err != nil {
+		t.Fatalf("ReadRegionPartial failed: %v", err)
+	}
+	want := []float32{4, 5, 6, 7, 8, 9, 10, 11}
+	if got := decodeFloat32(data); !slices.Equal(got, want) {
+		t.Errorf("expected rows 1-2 in full, got %v", got)
+	}
+
+	if _, err := reader.ReadRegionPartial(ctx, []int{3}, []int{2}); err == nil {
+		t.Error("expected an out-of-bounds prefix to be rejected")
+	}
+	if _, err := reader.ReadRegionPartial(ctx, []int{0, 0, 0}, []int{1, 1, 1}); err == nil {
+		t.Error("expected a prefix longer than the rank to be rejected")
+	}
+	if _, err := reader.ReadRegionPartial(ctx, []int{0}, []int{1, 1}); err == nil {
+		t.Error("expected mismatched start and shape to be rejected")
+	}
+}
+
+func TestReader_EdgeChunkPaddingNeverCopied(t *testing.T) {
+	// A 3x5 array in 2x3 chunks: the last axis ends one element into the
+	// second chunk column. Padding holds a sentinel that must never be read.
+	const pad = -999
+	values := make([]float32, 15)
+	for i := range values {
+		values[i] = float32(i)
+	}
+	chunks := chunkFloat32([]int{3, 5}, []int{2, 3}, values)
+	for key, chunk := range chunks {
+		var ci, cj int
+		fmt.Sscanf(key, "%d.%d", &ci, &cj)
+		for k := range chunk {
+			if ci*2+k/3 >= 3 || cj*3+k%3 >= 5 {
+				chunk[k] = pad
+			}
+		}
+	}
+	zarray := func(compressor string) string {
+		return `{
+			"zarr_format": 2,
+			"shape": [3, 5],
+			"chunks": [2, 3],
+			"dtype": "<f4",
+			"compressor": ` + compressor + `,
+			"fill_value": 0.0,
+			"order": "C"
+		}`
+	}
+	ctx := context.Background()
+
+	for _, compressor := range []string{"null", `{"id": "crc32c"}`} {
+		dir := t.TempDir()
+		raw := make(map[string][]byte, len(chunks))
+		for key, chunk := range chunks {
+			data := encodeLE(t, chunk)
+			if compressor != "null" {
+				data = binary.LittleEndian.AppendUint32(data, crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli)))
+			}
+			raw[key] = data
+		}
+		writeArray(t, dir, zarray(compressor), raw)
+		reader := openReader(t, dir)
+
+		full, err := reader.ReadFull(ctx)
+		if err != nil {
+			t.Fatalf("ReadFull failed: %v", err)
+		}
+		if got := decodeFloat32(full); !slices.Equal(got, values) {
+			t.Errorf("%s: ReadFull returned %v", compressor, got)
+		}
+
+		// Regions ending on the last valid column, through both the
+		// range-read and whole-chunk paths.
+		for _, start := range [][]int{{0, 3}, {1, 4}, {2, 0}} {
+			shape := []int{3 - start[0], 5 - start[1]}
+			region, err := reader.ReadRegion(ctx, start, shape)
+			if err != nil {
+				t.Fatalf("ReadRegion(%v, %v) failed: %v", start, shape, err)
+			}
+			for i, v := range decodeFloat32(region) {
+				want := values[(start[0]+i/shape[1])*5+start[1]+i%shape[1]]
+				if v != want {
+					t.Errorf("%s: ReadRegion(%v, %v) element %d: expected %v, got %v", compressor, start, shape, i, want, v)
+				}
+			}
+		}
+	}
+}
+
+func TestNewReaderFallback(t *testing.T) {
+	primary := t.TempDir()
+	secondary := t.TempDir()
+	writeFloat32Array(t, secondary, vector4, map[string][]float32{"0": {1, 2}, "1": {3, 4}})
+	ctx := context.Background()
+
+	reader, err := zarr.NewReaderFallback(ctx, []string{
+		"file:///" + filepath.ToSlash(primary),
+		"file:///" + filepath.ToSlash(secondary),
+	})
+	if err != nil {
+		t.Fatalf("NewReaderFallback failed: %v", err)
+	}
+	defer reader.Close()
+	data, err := reader.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+	if got := decodeFloat32(data); !slices.Equal(got, []float32{1, 2, 3, 4}) {
+		t.Errorf("expected the secondary's data, got %v", got)
+	}
+
+	_, err = zarr.NewReaderFallback(ctx, []string{"file:///" + filepath.ToSlash(primary), "nosuchscheme://bucket"})
+	if err == nil {
+		t.Fatal("expected an error when no path opens")
+	}
+	if !strings.Contains(err.Error(), "nosuchscheme") || !strings.Contains(err.Error(), ".zarray") {
+		t.Errorf("expected the error to report every path, got %v", err)
+	}
+}
+
+func TestReader_ReadRegionStrided(t *testing.T) {
+	base := openSequential4x4(t)
+	ctx := context.Background()
+
+	views := map[string]*zarr.Reader{
+		"plain":      base,
+		"flipped":    base.Flip([]int{0}),
+		"transposed": base.Transpose([]int{1, 0}).Flip([]int{1}),
+	}
+	cases := []struct{ start, stop, step []int }{
+		{[]int{0, 0}, []int{4, 4}, []int{2, 2}},
+		{[]int{1, 0}, []int{4, 4}, []int{2, 3}},
+		{[]int{0, 1}, []int{3, 4}, []int{1, 2}},
+		{[]int{3, 2}, []int{4, 3}, []int{5, 5}},
+		{[]int{0, 0}, []int{4, 4}, []int{1, 1}},
+	}
+	for name, reader := range views {
+		full, err := reader.ReadFull(ctx)
+		if err != nil {
+			t.Fatalf("%s: ReadFull failed: %v", name, err)
+		}
+		all := decodeFloat32(full)
+		cols := reader.Shape()[1]
+		for _, tc := range cases {
+			var want []float32
+			for i := tc.start[0]; i < tc.stop[0]; i += tc.step[0] {
+				for j := tc.start[1]; j < tc.stop[1]; j += tc.step[1] {
+					want = append(want, all[i*cols+j])
+				}
+			}
+			data, err := reader.ReadRegionStrided(ctx, tc.start, tc.stop, tc.step)
+			if err != nil {
+				t.Fatalf("%s: ReadRegionStrided(%v, %v, %v) failed: %v", name, tc.start, tc.stop, tc.step, err)
+			}
+			if got := decodeFloat32(data); !slices.Equal(got, want) {
+				t.Errorf("%s: ReadRegionStrided(%v, %v, %v) = %v, want %v", name, tc.start, tc.stop, tc.step, got, want)
+			}
+		}
+	}
+
+	if _, err := base.ReadRegionStrided(ctx, []int{0, 0}, []int{4, 4}, []int{0, 1}); err == nil {
+		t.Error("expected a zero step to be rejected")
+	}
+	if _, err := base.ReadRegionStrided(ctx, []int{0, 0}, []int{5, 4}, []int{1, 1}); err == nil {
+		t.Error("expected an out-of-bounds stop to be rejected")
+	}
+	if _, err := base.ReadRegionStrided(ctx, []int{2, 0}, []int{2, 4}, []int{1, 1}); err == nil {
+		t.Error("expected an empty selection to be rejected")
+	}
+}
+
+func TestReader_ChunksForRegion(t *testing.T) {
+	reader := openSequential4x4(t)
+
+	tests := []struct {
+		name         string
+		reader       *zarr.Reader
+		start, shape []int
+		want         [][]int
+	}{
+		{"one chunk", reader, []int{0, 0}, []int{2, 2}, [][]int{{0, 0}}},
+		{"center", reader, []int{1, 1}, []int{2, 2}, [][]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}}},
+		{"bottom row", reader, []int{3, 0}, []int{1, 4}, [][]int{{1, 0}, {1, 1}}},
+		{"flipped", reader.Flip([]int{0}), []int{3, 0}, []int{1, 4}, [][]int{{0, 0}, {0, 1}}},
+		{"transposed", reader.Transpose([]int{1, 0}), []int{0, 2}, []int{4, 1}, [][]int{{1, 0}, {1, 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.reader.ChunksForRegion(tt.start, tt.shape)
+			if err != nil {
+				t.Fatalf("ChunksForRegion failed: %v", err)
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := reader.ChunksForRegion([]int{3, 3}, []int{2, 1}); err == nil {
+		t.Error("expected an out-of-bounds region to be rejected")
+	}
+}
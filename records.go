@@ -0,0 +1,105 @@
+package zarr
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// StreamRecords writes one record per index along the array's first axis to
+// w, holding the index followed by the slice's values flattened in C order.
+// format is "csv", for rows of the form "index,v0,v1,...", or "json", for
+// JSON lines of the form {"index":i,"values":[v0,v1,...]} in which NaN and
+// infinities become null. The array is read one chunk-high band at a time,
+// so memory stays bounded by a band rather than the whole array.
+func (r *Reader) StreamRecords(ctx context.Context, w io.Writer, format string) error {
+	if r.viewErr != nil {
+		return r.viewErr
+	}
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("unsupported record format %q, expected \"csv\" or \"json\"", format)
+	}
+	shape := r.Shape()
+	if len(shape) == 0 {
+		return fmt.Errorf("record export requires at least one dimension")
+	}
+	name, _, err := ParseDType(r.meta.DType)
+	if err != nil {
+		return fmt.Errorf("invalid dtype: %w", err)
+	}
+	if typ, ok := goTypes[name]; !ok || !isNumericKind(typ.Kind()) || r.rawItemSize > 0 {
+		return fmt.Errorf("record export requires a numeric dtype, got %s", r.meta.DType)
+	}
+
+	width := 1
+	for _, n := range shape[1:] {
+		width *= n
+	}
+	band := r.meta.Chunks[r.storageAxis(0)]
+
+	bw := bufio.NewWriter(w)
+	cw := csv.NewWriter(bw)
+	record := make([]string, width+1)
+	for first := 0; first < shape[0]; first += band {
+		start := make([]int, len(shape))
+		regionShape := append([]int(nil), shape...)
+		start[0] = first
+		regionShape[0] = min(band, shape[0]-first)
+		values, _, err := r.ReadRegionReflect(ctx, start, regionShape)
+		if err != nil {
+			return err
+		}
+
+		for row := 0; row < regionShape[0]; row++ {
+			index := first + row
+			if format == "csv" {
+				record[0] = strconv.Itoa(index)
+				for i := 0; i < width; i++ {
+					record[i+1] = r.formatElement(values, row*width+i)
+				}
+				if err := cw.Write(record); err != nil {
+					return fmt.Errorf("failed to write record %d: %w", index, err)
+				}
+				continue
+			}
+			if err := r.writeJSONRecord(bw, index, values, row*width, width); err != nil {
+				return fmt.Errorf("failed to write record %d: %w", index, err)
+			}
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write records: %w", err)
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write records: %w", err)
+	}
+	return nil
+}
+
+// writeJSONRecord writes elements [offset, offset+n) of values as a JSON
+// line.
+func (r *Reader) writeJSONRecord(w *bufio.Writer, index int, values reflect.Value, offset, n int) error {
+	w.WriteString(`{"index":`)
+	w.WriteString(strconv.Itoa(index))
+	w.WriteString(`,"values":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		elem := values.Index(offset + i)
+		if elem.CanFloat() && (math.IsNaN(elem.Float()) || math.IsInf(elem.Float(), 0)) {
+			w.WriteString("null")
+			continue
+		}
+		w.WriteString(r.formatElement(values, offset+i))
+	}
+	_, err := w.WriteString("]}\n")
+	return err
+}
@@ -0,0 +1,56 @@
+package zarr_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReader_StreamRecords(t *testing.T) {
+	// 5 rows in chunks of 2, so the last band is partial.
+	reader := openSequential(t, []int{5, 2, 3}, []int{2, 2, 2})
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	if err := reader.StreamRecords(ctx, &buf, "csv"); err != nil {
+		t.Fatalf("StreamRecords csv failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 CSV records, got %d: %q", len(lines), buf.String())
+	}
+	if want := "3,18,19,20,21,22,23"; lines[3] != want {
+		t.Errorf("expected record %q, got %q", want, lines[3])
+	}
+
+	buf.Reset()
+	if err := reader.StreamRecords(ctx, &buf, "json"); err != nil {
+		t.Fatalf("StreamRecords json failed: %v", err)
+	}
+	type record struct {
+		Index  int       `json:"index"`
+		Values []float64 `json:"values"`
+	}
+	var records []record
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, rec)
+	}
+	if len(records) != 5 {
+		t.Fatalf("expected 5 JSON records, got %d", len(records))
+	}
+	if rec := records[4]; rec.Index != 4 || len(rec.Values) != 6 || rec.Values[0] != 24 || rec.Values[5] != 29 {
+		t.Errorf("unexpected last record %+v", rec)
+	}
+
+	if err := reader.StreamRecords(ctx, &buf, "xml"); err == nil {
+		t.Error("expected an unsupported format to be rejected")
+	}
+}
@@ -0,0 +1,122 @@
+package zarr
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// ReadResult is the outcome of a read together with the layout needed to
+// interpret its bytes.
+type ReadResult struct {
+	// Bytes holds the elements, always in little-endian byte order whatever
+	// byte order DType declares.
+	Bytes []byte
+	// Shape is the extent of the result along each dimension.
+	Shape []int
+	// DType is the array's numpy-style dtype, e.g. "<f4".
+	DType DType
+	// Order is the element order of Bytes, OrderC or OrderF.
+	Order Order
+	// ItemSize is the size of one element in bytes. It differs from the
+	// dtype's size for views created with WithRawDType.
+	ItemSize int
+}
+
+// ReadFullResult reads the whole array like ReadFull and returns it with
+// its layout.
+func (r *Reader) ReadFullResult(ctx context.Context, opts ...ReadOption) (*ReadResult, error) {
+	data, err := r.ReadFull(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return r.result(data, r.Shape(), OrderC)
+}
+
+// ReadRegionResult reads a region like ReadRegion and returns it with its
+// layout.
+func (r *Reader) ReadRegionResult(ctx context.Context, start, shape []int, opts ...ReadOption) (*ReadResult, error) {
+	data, err := r.ReadRegion(ctx, start, shape, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return r.result(data, slices.Clone(shape), OrderC)
+}
+
+// ReadChunkResult reads a chunk like ReadChunk and returns it with its
+// layout, which is the array's storage order.
+func (r *Reader) ReadChunkResult(ctx context.Context, coords []int, opts ...ReadOption) (*ReadResult, error) {
+	data, err := r.ReadChunk(ctx, coords, opts...)
+	if err != nil {
+		return nil, err
+	}
+	order := Order(r.meta.Order)
+	if order == "" {
+		order = OrderC
+	}
+	return r.result(data, slices.Clone(r.meta.Chunks), order)
+}
+
+func (r *Reader) result(data []byte, shape []int, order Order) (*ReadResult, error) {
+	itemSize, err := r.itemSize()
+	if err != nil {
+		return nil, err
+	}
+	return &ReadResult{Bytes: data, Shape: shape, DType: DType(r.meta.DType), Order: order, ItemSize: itemSize}, nil
+}
+
+// Float32 decodes the result's elements, which must be of dtype f4.
+func (res *ReadResult) Float32() ([]float32, error) {
+	return decodeResult[float32](res)
+}
+
+// Float64 decodes the result's elements, which must be of dtype f8.
+func (res *ReadResult) Float64() ([]float64, error) {
+	return decodeResult[float64](res)
+}
+
+// Int32 decodes the result's elements, which must be of dtype i4.
+func (res *ReadResult) Int32() ([]int32, error) {
+	return decodeResult[int32](res)
+}
+
+// Int64 decodes the result's elements, which must be of dtype i8.
+func (res *ReadResult) Int64() ([]int64, error) {
+	return decodeResult[int64](res)
+}
+
+// Uint8 decodes the result's elements, which must be of dtype u1.
+func (res *ReadResult) Uint8() ([]uint8, error) {
+	return decodeResult[uint8](res)
+}
+
+// Complex64 decodes the result's elements, which must be of dtype
+// Complex64 in either byte order, from interleaved float32 pairs.
+func (res *ReadResult) Complex64() ([]complex64, error) {
+	return decodeComplex[complex64](res, Complex64)
+}
+
+// Complex128 decodes the result's elements, which must be of dtype
+// Complex128 in either byte order, from interleaved float64 pairs.
+func (res *ReadResult) Complex128() ([]complex128, error) {
+	return decodeComplex[complex128](res, Complex128)
+}
+
+// decodeComplex decodes a result whose dtype matches want up to byte order.
+func decodeComplex[T complex64 | complex128](res *ReadResult, want DType) ([]T, error) {
+	if res.DType.ReflectType() != want.ReflectType() {
+		return nil, fmt.Errorf("dtype %s cannot be decoded as %s", res.DType, want)
+	}
+	return decodeResult[T](res)
+}
+
+func decodeResult[T Numeric](res *ReadResult) ([]T, error) {
+	if err := checkDType[T](string(res.DType)); err != nil {
+		return nil, err
+	}
+	var zero T
+	if size := res.DType.Size(); size != res.ItemSize {
+		return nil, fmt.Errorf("raw %d-byte items cannot be decoded as %T", res.ItemSize, zero)
+	}
+	return decodeAs[T](res.Bytes)
+}
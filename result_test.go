@@ -0,0 +1,110 @@
+package zarr_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_ReadRegionResult(t *testing.T) {
+	reader := openSequential4x4(t)
+	ctx := context.Background()
+
+	res, err := reader.ReadRegionResult(ctx, []int{1, 1}, []int{2, 3})
+	if err != nil {
+		t.Fatalf("ReadRegionResult failed: %v", err)
+	}
+	if !slices.Equal(res.Shape, []int{2, 3}) || res.DType != "<f4" || res.Order != zarr.OrderC || res.ItemSize != 4 {
+		t.Errorf("unexpected layout: shape %v, dtype %s, order %s, item size %d", res.Shape, res.DType, res.Order, res.ItemSize)
+	}
+	got, err := res.Float32()
+	if err != nil {
+		t.Fatalf("Float32 failed: %v", err)
+	}
+	if want := []float32{5, 6, 7, 9, 10, 11}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if _, err := res.Int64(); err == nil {
+		t.Error("expected Int64 to reject a <f4 result")
+	}
+
+	full, err := reader.ReadFullResult(ctx)
+	if err != nil {
+		t.Fatalf("ReadFullResult failed: %v", err)
+	}
+	if !slices.Equal(full.Shape, []int{4, 4}) || len(full.Bytes) != 64 {
+		t.Errorf("expected a 4x4 result of 64 bytes, got shape %v and %d bytes", full.Shape, len(full.Bytes))
+	}
+
+	raw, err := reader.WithRawDType(2).ReadFullResult(ctx)
+	if err != nil {
+		t.Fatalf("raw ReadFullResult failed: %v", err)
+	}
+	if raw.ItemSize != 2 {
+		t.Errorf("expected a raw item size of 2, got %d", raw.ItemSize)
+	}
+	if _, err := raw.Float32(); err == nil {
+		t.Error("expected Float32 to reject a raw view result")
+	}
+}
+
+func TestReader_ReadChunkResult(t *testing.T) {
+	dir := t.TempDir()
+	writeArray(t, dir, int64Vector6, map[string][]byte{
+		"0": encodeLE(t, []int64{-1, 2, -3, 4}),
+	})
+	reader := openReader(t, dir)
+
+	res, err := reader.ReadChunkResult(context.Background(), []int{0})
+	if err != nil {
+		t.Fatalf("ReadChunkResult failed: %v", err)
+	}
+	if !slices.Equal(res.Shape, []int{4}) || res.Order != zarr.OrderC {
+		t.Errorf("expected a C-order chunk of shape [4], got %s %v", res.Order, res.Shape)
+	}
+	got, err := res.Int64()
+	if err != nil {
+		t.Fatalf("Int64 failed: %v", err)
+	}
+	if want := []int64{-1, 2, -3, 4}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if _, err := res.Float64(); err == nil {
+		t.Error("expected Float64 to reject a <i8 result")
+	}
+}
+
+func TestReader_ReadChunkResultFortran(t *testing.T) {
+	dir := t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [2, 2],
+		"chunks": [2, 2],
+		"dtype": "<i4",
+		"compressor": null,
+		"fill_value": 0,
+		"order": "F"
+	}`, map[string][]byte{
+		"0.0": encodeLE(t, []int32{1, 3, 2, 4}),
+	})
+	reader := openReader(t, dir)
+	ctx := context.Background()
+
+	res, err := reader.ReadChunkResult(ctx, []int{0, 0})
+	if err != nil {
+		t.Fatalf("ReadChunkResult failed: %v", err)
+	}
+	if res.Order != zarr.OrderF || res.DType != zarr.DType("<i4") {
+		t.Errorf("expected an F-order <i4 chunk, got %s %s", res.Order, res.DType)
+	}
+
+	full, err := reader.ReadFullResult(ctx)
+	if err != nil {
+		t.Fatalf("ReadFullResult failed: %v", err)
+	}
+	if full.Order != zarr.OrderC {
+		t.Errorf("expected ReadFullResult to return C order, got %s", full.Order)
+	}
+}
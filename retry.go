@@ -0,0 +1,89 @@
+package zarr
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"gocloud.dev/gcerrors"
+)
+
+// defaultRetryDelay is the delay before the first retry when a RetryPolicy
+// leaves BaseDelay unset.
+const defaultRetryDelay = 100 * time.Millisecond
+
+// maxRetryDelay caps the delay between two attempts.
+const maxRetryDelay = 30 * time.Second
+
+// RetryPolicy controls how chunk fetches that fail with a transient store
+// error are retried. Missing chunks are never retried, since they
+// legitimately stand for fill-valued chunks.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero
+	// disables retrying.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry, doubled for every
+	// later one and randomized by up to half to spread out concurrent
+	// retries. It defaults to 100ms.
+	BaseDelay time.Duration
+}
+
+// WithRetryPolicy returns a view of the array that retries chunk fetches
+// according to policy.
+func (r *Reader) WithRetryPolicy(policy RetryPolicy) *Reader {
+	v := r.view()
+	v.retry = policy
+	return v
+}
+
+// withRetry calls fetch until it succeeds, fails with an error that is not
+// worth retrying, or the reader's RetryPolicy is exhausted, and returns its
+// last error. It gives up early with the context's error once ctx is done.
+func (r *Reader) withRetry(ctx context.Context, key string, fetch func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fetch()
+		if err == nil || attempt >= r.retry.MaxRetries || !retryable(ctx, err) {
+			return err
+		}
+
+		delay := r.retry.delay(attempt)
+		r.debug(ctx, "retrying chunk fetch", slog.String("key", key), slog.Int("attempt", attempt+1), slog.Duration("delay", delay), slog.Any("error", err))
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// delay returns the randomized backoff before retry number attempt+1.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.BaseDelay
+	if d <= 0 {
+		d = defaultRetryDelay
+	}
+	for range attempt {
+		if d >= maxRetryDelay {
+			break
+		}
+		d *= 2
+	}
+	d = min(d, maxRetryDelay)
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryable reports whether a failed fetch may succeed when tried again.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch gcerrors.Code(err) {
+	case gcerrors.NotFound, gcerrors.InvalidArgument, gcerrors.PermissionDenied,
+		gcerrors.FailedPrecondition, gcerrors.Unimplemented, gcerrors.Canceled:
+		return false
+	}
+	return true
+}
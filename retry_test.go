@@ -0,0 +1,100 @@
+package zarr_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+// failFirst makes the first n reads of every key fail with a transient
+// error.
+func failFirst(fb *fakeBucket, n int) {
+	var mu sync.Mutex
+	failures := map[string]int{}
+	fb.beforeRead = func(ctx context.Context, key string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if failures[key] < n {
+			failures[key]++
+			return errors.New("503 service unavailable")
+		}
+		return nil
+	}
+}
+
+func TestReader_WithRetryPolicy(t *testing.T) {
+	fb, _ := gzipArray(t, 8, 4)
+	reader := openFake(t, fb)
+	ctx := context.Background()
+	want, err := reader.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+
+	failFirst(fb, 2)
+	if _, err := reader.ReadChunk(ctx, []int{0, 0}); err == nil {
+		t.Fatal("expected a transient failure without a retry policy")
+	}
+
+	failFirst(fb, 2)
+	fb.resetReads()
+	retrying := reader.WithRetryPolicy(zarr.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond})
+	got, err := retrying.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull with retries failed: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("retried ReadFull returned different data")
+	}
+	if n := len(fb.readsOf("1.1")); n != 3 {
+		t.Errorf("expected chunk 1.1 to be fetched 3 times, got %d", n)
+	}
+
+	failFirst(fb, 3)
+	if _, err := retrying.ReadChunk(ctx, []int{0, 0}); err == nil {
+		t.Error("expected the read to fail once the retries are exhausted")
+	}
+}
+
+func TestReader_WithRetryPolicyNotFound(t *testing.T) {
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(int64Vector6),
+		"0":       encodeLE(t, []int64{1, 2, 3, 4}),
+	})
+	reader := openFake(t, fb).WithRetryPolicy(zarr.RetryPolicy{MaxRetries: 5, BaseDelay: time.Hour})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := reader.ReadFull(context.Background())
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("ReadFull failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("missing chunks were retried")
+	}
+	if n := len(fb.readsOf("1")); n != 1 {
+		t.Errorf("expected the missing chunk to be fetched once, got %d", n)
+	}
+}
+
+func TestReader_WithRetryPolicyCancel(t *testing.T) {
+	fb, _ := gzipArray(t, 4, 4)
+	reader := openFake(t, fb).WithRetryPolicy(zarr.RetryPolicy{MaxRetries: 5, BaseDelay: time.Hour})
+	failFirst(fb, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	_, err := reader.ReadChunk(ctx, []int{0, 0})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the backoff to end with context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package zarr
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// numericAttr returns the attribute name as a float64, or def when it is
+// absent. Like fill_value, the attribute may be one of the strings "NaN",
+// "Infinity" and "-Infinity", which JSON cannot express as numbers.
+func numericAttr(attrs map[string]any, name string, def float64) (float64, bool, error) {
+	v, ok := attrs[name]
+	if !ok || v == nil {
+		return def, false, nil
+	}
+	switch v := v.(type) {
+	case float64:
+		return v, true, nil
+	case string:
+		if f, ok := fillFloat64(v); ok {
+			return f, true, nil
+		}
+	}
+	return 0, false, fmt.Errorf("attribute %s is not a number: %v", name, v)
+}
+
+// ReadRegionScaled reads a region and decodes it following the CF
+// conventions used by xarray and NetCDF: each stored value v becomes
+// v*scale_factor + add_offset, using the attributes of that name in .zattrs,
+// and values equal to _FillValue, compared at the precision of the stored
+// dtype, become NaN. Arrays without these attributes
+// are simply converted to float64. Any WithElementTransform function is
+// applied to the decoded values.
+func (r *Reader) ReadRegionScaled(ctx context.Context, start, shape []int, opts ...ReadOption) ([]float64, error) {
+	attrs, err := r.Attributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scale, _, err := numericAttr(attrs, "scale_factor", 1)
+	if err != nil {
+		return nil, err
+	}
+	offset, _, err := numericAttr(attrs, "add_offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	fill, hasFill, err := numericAttr(attrs, "_FillValue", 0)
+	if err != nil {
+		return nil, err
+	}
+	// A float32 element widened to float64 only equals the fill value if
+	// the fill value is rounded the same way.
+	fill = storedFloat(r.meta.DType, fill)
+
+	raw, _, err := r.ReadRegionReflect(ctx, start, shape, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]float64, raw.Len())
+	for i := range out {
+		v, err := elementFloat(raw.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		if hasFill && (v == fill || math.IsNaN(fill) && math.IsNaN(v)) {
+			out[i] = math.NaN()
+			continue
+		}
+		out[i] = r.transformed(v*scale + offset)
+	}
+	return out, nil
+}
@@ -0,0 +1,161 @@
+package zarr_test
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReader_ReadRegionScaled(t *testing.T) {
+	dir := t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [4],
+		"chunks": [2],
+		"dtype": "<i2",
+		"compressor": null,
+		"fill_value": -32768,
+		"order": "C"
+	}`, map[string][]byte{
+		"0": encodeLE(t, []int16{0, 100}),
+		"1": encodeLE(t, []int16{-32768, -200}),
+	})
+	attrs := `{"scale_factor": 0.01, "add_offset": 273.15, "_FillValue": -32768, "units": "K"}`
+	if err := os.WriteFile(filepath.Join(dir, ".zattrs"), []byte(attrs), 0644); err != nil {
+		t.Fatalf("failed to write .zattrs: %v", err)
+	}
+	reader := openReader(t, dir)
+
+	got, err := reader.ReadRegionScaled(context.Background(), []int{0}, []int{4})
+	if err != nil {
+		t.Fatalf("ReadRegionScaled failed: %v", err)
+	}
+
+	want := []float64{273.15, 274.15, math.NaN(), 271.15}
+	for i := range want {
+		if math.IsNaN(want[i]) {
+			if !math.IsNaN(got[i]) {
+				t.Errorf("element %d: expected NaN for the fill value, got %v", i, got[i])
+			}
+			continue
+		}
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("element %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestReader_ReadRegionScaledWithoutAttrs(t *testing.T) {
+	reader := openSequential4x4(t)
+
+	got, err := reader.ReadRegionScaled(context.Background(), []int{1, 1}, []int{1, 2})
+	if err != nil {
+		t.Fatalf("ReadRegionScaled failed: %v", err)
+	}
+	if len(got) != 2 || got[0] != 5 || got[1] != 6 {
+		t.Errorf("expected the raw values [5 6], got %v", got)
+	}
+}
+
+func TestReader_WithElementTransform(t *testing.T) {
+	dir := t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [4],
+		"chunks": [2],
+		"dtype": "<i2",
+		"compressor": null,
+		"fill_value": -32768,
+		"order": "C"
+	}`, map[string][]byte{
+		"0": encodeLE(t, []int16{0, 100}),
+		"1": encodeLE(t, []int16{-32768, -200}),
+	})
+	attrs := `{"scale_factor": 0.01, "add_offset": 273.15, "_FillValue": -32768}`
+	if err := os.WriteFile(filepath.Join(dir, ".zattrs"), []byte(attrs), 0644); err != nil {
+		t.Fatalf("failed to write .zattrs: %v", err)
+	}
+	celsius := openReader(t, dir).WithElementTransform(func(v float64) float64 { return v - 273.15 })
+
+	got, err := celsius.ReadRegionScaled(context.Background(), []int{0}, []int{4})
+	if err != nil {
+		t.Fatalf("ReadRegionScaled failed: %v", err)
+	}
+	want := []float64{0, 1, math.NaN(), -2}
+	for i := range want {
+		if math.IsNaN(want[i]) {
+			if !math.IsNaN(got[i]) {
+				t.Errorf("element %d: expected NaN for the fill value, got %v", i, got[i])
+			}
+			continue
+		}
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("element %d: expected %v, got %v", i, want[i], got[i])
+		}
+	}
+
+	doubled := celsius.WithElementTransform(func(v float64) float64 { return v * 2 })
+	v, err := doubled.Lazy(context.Background()).At(3)
+	if err != nil {
+		t.Fatalf("At failed: %v", err)
+	}
+	if math.Abs(v-(-200-273.15)*2) > 1e-9 {
+		t.Errorf("expected the raw value to pass through both transforms, got %v", v)
+	}
+}
+
+// float32Pair is a single-chunk <f4 array of two elements.
+const float32Pair = `{
+	"zarr_format": 2,
+	"shape": [2],
+	"chunks": [2],
+	"dtype": "<f4",
+	"compressor": null,
+	"fill_value": null,
+	"order": "C"
+}`
+
+func TestReader_ReadRegionScaledFloat32Fill(t *testing.T) {
+	for _, tc := range []struct {
+		name, fill string
+		values     []float32
+		want       []float64
+	}{
+		// 0.1 is stored rounded to float32 precision.
+		{"rounded", `0.1`, []float32{1.5, 0.1}, []float64{2.5, math.NaN()}},
+		{"nan", `"NaN"`, []float32{1.5, float32(math.NaN())}, []float64{2.5, math.NaN()}},
+		{"infinity", `"-Infinity"`, []float32{float32(math.Inf(-1)), 2}, []float64{math.NaN(), 3}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFloat32Array(t, dir, float32Pair, map[string][]float32{"0": tc.values})
+			attrs := `{"add_offset": 1, "_FillValue": ` + tc.fill + `}`
+			if err := os.WriteFile(filepath.Join(dir, ".zattrs"), []byte(attrs), 0644); err != nil {
+				t.Fatalf("failed to write .zattrs: %v", err)
+			}
+
+			got, err := openReader(t, dir).ReadRegionScaled(context.Background(), []int{0}, []int{2})
+			if err != nil {
+				t.Fatalf("ReadRegionScaled failed: %v", err)
+			}
+			for i, want := range tc.want {
+				if math.IsNaN(want) != math.IsNaN(got[i]) || !math.IsNaN(want) && got[i] != want {
+					t.Errorf("element %d: expected %v, got %v", i, want, got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReader_ReadRegionScaledBadAttr(t *testing.T) {
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, float32Pair, map[string][]float32{"0": {1, 2}})
+	if err := os.WriteFile(filepath.Join(dir, ".zattrs"), []byte(`{"scale_factor": "twice"}`), 0644); err != nil {
+		t.Fatalf("failed to write .zattrs: %v", err)
+	}
+	if _, err := openReader(t, dir).ReadRegionScaled(context.Background(), []int{0}, []int{2}); err == nil {
+		t.Error("expected an error for a non-numeric scale_factor")
+	}
+}
@@ -0,0 +1,68 @@
+package zarr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Slice selects elements along one dimension like start:stop:step in numpy.
+// Negative Start and Stop count back from the end of the dimension, and Stop
+// is the end of the dimension unless HasStop is set; a Stop past the end is
+// clamped to it. Step must be positive.
+type Slice struct {
+	Start, Stop, Step int
+	HasStop           bool
+}
+
+// ReadSlices reads the elements selected by one Slice per dimension of the
+// view and returns them in C order, like ReadRegionStrided. For example,
+// []Slice{{Start: -1, Step: 1}, {Start: 2, Step: 1}} reads the last row
+// from its third column on.
+func (r *Reader) ReadSlices(ctx context.Context, slices []Slice, opts ...ReadOption) ([]byte, error) {
+	if r.viewErr != nil {
+		return nil, r.viewErr
+	}
+	viewShape := r.Shape()
+	if len(slices) != len(viewShape) {
+		return nil, fmt.Errorf("got %d slices for %d dimensions", len(slices), len(viewShape))
+	}
+	start := make([]int, len(slices))
+	stop := make([]int, len(slices))
+	step := make([]int, len(slices))
+	for i, s := range slices {
+		var err error
+		start[i], stop[i], step[i], err = s.resolve(viewShape[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid slice at dimension %d: %w", i, err)
+		}
+	}
+	return r.ReadRegionStrided(ctx, start, stop, step, opts...)
+}
+
+// resolve returns the absolute start, stop and step of s along a dimension
+// of length n.
+func (s Slice) resolve(n int) (start, stop, step int, err error) {
+	start, stop, step = s.Start, n, s.Step
+	if s.HasStop {
+		stop = s.Stop
+	}
+	if start < 0 {
+		start += n
+	}
+	if stop < 0 {
+		stop += n
+	}
+	stop = min(stop, n)
+	switch {
+	case step == 0:
+		return 0, 0, 0, errors.New("slice step cannot be zero")
+	case step < 0:
+		return 0, 0, 0, fmt.Errorf("negative step %d is not supported", s.Step)
+	case start < 0 || start >= n:
+		return 0, 0, 0, fmt.Errorf("start %d out of range for length %d", s.Start, n)
+	case stop <= start:
+		return 0, 0, 0, fmt.Errorf("stop %d out of range for start %d and length %d", s.Stop, s.Start, n)
+	}
+	return start, stop, step, nil
+}
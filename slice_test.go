@@ -0,0 +1,59 @@
+package zarr_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_ReadSlices(t *testing.T) {
+	reader := openSequential4x4(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name   string
+		slices []zarr.Slice
+		want   []float32
+	}{
+		{"negative start", []zarr.Slice{{Start: -1, Step: 1}, {Start: -2, Step: 1}}, []float32{14, 15}},
+		{"omitted stop", []zarr.Slice{{Start: 2, Step: 1}, {Start: 1, Step: 2}}, []float32{9, 11, 13, 15}},
+		{"negative stop", []zarr.Slice{{Start: 0, Stop: -3, HasStop: true, Step: 1}, {Start: 1, Stop: 3, HasStop: true, Step: 1}}, []float32{1, 2}},
+		{"whole array", []zarr.Slice{{Step: 1}, {Step: 3}}, []float32{0, 3, 4, 7, 8, 11, 12, 15}},
+		{"stop past the end", []zarr.Slice{{Start: 3, Stop: 100, HasStop: true, Step: 1}, {Start: 2, Stop: 5, HasStop: true, Step: 1}}, []float32{14, 15}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := reader.ReadSlices(ctx, tc.slices)
+			if err != nil {
+				t.Fatalf("ReadSlices failed: %v", err)
+			}
+			if got := decodeFloat32(data); !slices.Equal(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	for _, bad := range [][]zarr.Slice{
+		{{Start: -5, Step: 1}, {Step: 1}},
+		{{Start: 4, Step: 1}, {Step: 1}},
+		{{Start: 2, Stop: -2, HasStop: true, Step: 1}, {Step: 1}},
+		{{Stop: -5, HasStop: true, Step: 1}, {Step: 1}},
+		{{Step: -1}, {Step: 1}},
+		{{Step: 1}},
+	} {
+		if _, err := reader.ReadSlices(ctx, bad); err == nil {
+			t.Errorf("expected %+v to be rejected", bad)
+		}
+	}
+}
+
+func TestReader_ReadSlicesZeroStep(t *testing.T) {
+	reader := openSequential4x4(t)
+
+	_, err := reader.ReadSlices(context.Background(), []zarr.Slice{{Step: 1}, {Start: 1}})
+	if err == nil || !strings.Contains(err.Error(), "slice step cannot be zero") || !strings.Contains(err.Error(), "dimension 1") {
+		t.Errorf("expected a zero step error at dimension 1, got %v", err)
+	}
+}
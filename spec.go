@@ -0,0 +1,139 @@
+package zarr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// openSpec is the subset of a tensorstore driver spec understood by OpenSpec.
+type openSpec struct {
+	Driver  string          `json:"driver"`
+	KVStore json.RawMessage `json:"kvstore"`
+	Path    string          `json:"path"`
+}
+
+// kvStoreSpec is a tensorstore kvstore given in its object form.
+type kvStoreSpec struct {
+	Driver string `json:"driver"`
+	Bucket string `json:"bucket"`
+	Path   string `json:"path"`
+}
+
+// OpenSpec opens an array described by a tensorstore-style spec such as
+//
+//	{"driver": "zarr", "kvstore": {"driver": "file", "path": "/data/x.zarr/"}}
+//
+// The kvstore may be an object using the "file", "gcs" or "s3" drivers, or a
+// URL string (file://, gs:// or s3://), and an optional top-level "path"
+// selects an array below it. Only the "zarr" (V2) driver is supported. Spec
+// fields this package cannot honour, such as "metadata", "dtype" or
+// "transform", are rejected rather than ignored.
+func OpenSpec(ctx context.Context, specJSON []byte) (*Reader, error) {
+	var spec openSpec
+	dec := json.NewDecoder(bytes.NewReader(specJSON))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	switch spec.Driver {
+	case "zarr":
+	case "zarr3":
+		return nil, fmt.Errorf("unsupported spec driver %q: only Zarr V2 arrays can be read", spec.Driver)
+	default:
+		return nil, fmt.Errorf("unsupported spec driver %q", spec.Driver)
+	}
+	if len(spec.KVStore) == 0 {
+		return nil, fmt.Errorf("spec has no kvstore")
+	}
+
+	bucketURL, prefix, err := parseKVStore(spec.KVStore)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := OpenSharedBucket(ctx, bucketURL)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := store.OpenArray(ctx, path.Join(prefix, spec.Path))
+	store.Close()
+	if err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// parseKVStore returns the gocloud bucket URL and the key prefix within the
+// bucket for a kvstore spec.
+func parseKVStore(raw json.RawMessage) (bucketURL, prefix string, err error) {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		u, err := url.Parse(s)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid kvstore URL %q: %w", s, err)
+		}
+		switch u.Scheme {
+		case "file":
+			return "file://" + u.Path, "", nil
+		case "gs", "s3":
+			return u.Scheme + "://" + u.Host, strings.TrimPrefix(u.Path, "/"), nil
+		default:
+			return "", "", fmt.Errorf("unsupported kvstore URL scheme %q", u.Scheme)
+		}
+	}
+
+	var kv kvStoreSpec
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&kv); err != nil {
+		return "", "", fmt.Errorf("failed to parse kvstore: %w", err)
+	}
+	switch kv.Driver {
+	case "file":
+		if kv.Bucket != "" {
+			return "", "", fmt.Errorf("file kvstore does not take a bucket")
+		}
+		if kv.Path == "" {
+			return "", "", fmt.Errorf("file kvstore has no path")
+		}
+		u, err := fileURL(kv.Path)
+		if err != nil {
+			return "", "", err
+		}
+		return u, "", nil
+	case "gcs", "s3":
+		if kv.Bucket == "" {
+			return "", "", fmt.Errorf("%s kvstore has no bucket", kv.Driver)
+		}
+		scheme := "s3"
+		if kv.Driver == "gcs" {
+			scheme = "gs"
+		}
+		return scheme + "://" + kv.Bucket, kv.Path, nil
+	default:
+		return "", "", fmt.Errorf("unsupported kvstore driver %q", kv.Driver)
+	}
+}
+
+// fileURL returns the file:// bucket URL of a local directory. Relative
+// paths are taken from the working directory, and characters such as spaces
+// are escaped.
+func fileURL(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve file kvstore path %q: %w", dir, err)
+	}
+	p := filepath.ToSlash(abs)
+	if !strings.HasPrefix(p, "/") {
+		// A Windows path such as C:/data.
+		p = "/" + p
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String(), nil
+}
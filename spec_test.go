@@ -0,0 +1,87 @@
+package zarr_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestOpenSpec(t *testing.T) {
+	root := t.TempDir()
+	writeFloat32Array(t, filepath.Join(root, "group", "a"), vector4, map[string][]float32{
+		"0": {1, 2}, "1": {3, 4},
+	})
+	rootPath, _ := json.Marshal(filepath.ToSlash(root) + "/")
+	rootURL, _ := json.Marshal("file://" + filepath.ToSlash(root) + "/")
+	ctx := context.Background()
+
+	specs := map[string]string{
+		"object": `{"driver": "zarr", "kvstore": {"driver": "file", "path": ` + string(rootPath) + `}, "path": "group/a"}`,
+		"url":    `{"driver": "zarr", "kvstore": ` + string(rootURL) + `, "path": "group/a"}`,
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			reader, err := zarr.OpenSpec(ctx, []byte(spec))
+			if err != nil {
+				t.Fatalf("OpenSpec failed: %v", err)
+			}
+			defer reader.Close()
+
+			data, err := reader.ReadFull(ctx)
+			if err != nil {
+				t.Fatalf("ReadFull failed: %v", err)
+			}
+			if got := decodeFloat32(data); !reflect.DeepEqual(got, []float32{1, 2, 3, 4}) {
+				t.Errorf("expected [1 2 3 4], got %v", got)
+			}
+		})
+	}
+}
+
+func TestOpenSpec_RelativeFilePath(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "my data")
+	writeFloat32Array(t, filepath.Join(root, "a"), vector4, map[string][]float32{
+		"0": {1, 2}, "1": {3, 4},
+	})
+	t.Chdir(filepath.Dir(root))
+
+	spec := `{"driver": "zarr", "kvstore": {"driver": "file", "path": "my data/"}, "path": "a"}`
+	reader, err := zarr.OpenSpec(context.Background(), []byte(spec))
+	if err != nil {
+		t.Fatalf("OpenSpec failed: %v", err)
+	}
+	defer reader.Close()
+
+	data, err := reader.ReadFull(context.Background())
+	if err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+	if got := decodeFloat32(data); !reflect.DeepEqual(got, []float32{1, 2, 3, 4}) {
+		t.Errorf("expected [1 2 3 4], got %v", got)
+	}
+}
+
+func TestOpenSpec_Unsupported(t *testing.T) {
+	tests := map[string]struct {
+		spec string
+		want string
+	}{
+		"zarr3":          {`{"driver": "zarr3", "kvstore": "file:///tmp/x"}`, "Zarr V2"},
+		"unknown field":  {`{"driver": "zarr", "kvstore": "file:///tmp/x", "dtype": "float32"}`, "dtype"},
+		"kvstore driver": {`{"driver": "zarr", "kvstore": {"driver": "memory"}}`, "memory"},
+		"no kvstore":     {`{"driver": "zarr"}`, "no kvstore"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := zarr.OpenSpec(context.Background(), []byte(tt.spec))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error mentioning %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
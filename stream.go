@@ -0,0 +1,85 @@
+package zarr
+
+import (
+	"context"
+	"errors"
+)
+
+// errStreamStopped ends the chunk walk of StreamChunks early.
+var errStreamStopped = errors.New("stream stopped")
+
+// ChunkResult is one chunk emitted by Reader.StreamChunks. Err is set on the
+// final result sent when a read fails, in which case Data is nil.
+type ChunkResult struct {
+	// Coords are the chunk's coordinates in the stored chunk grid.
+	Coords []int
+	// Start and Shape give the region of the view the chunk covers, clipped
+	// to the array's edge.
+	Start []int
+	Shape []int
+	// Data holds the region's elements in C order, as ReadRegion returns
+	// them.
+	Data []byte
+	Err  error
+}
+
+// StreamChunks reads the array one stored chunk at a time, in C order of the
+// chunk grid, and sends each chunk's region on the returned channel, so that
+// arrays larger than memory can be processed without ReadFull. Reads happen
+// as the consumer receives, so a slow consumer applies backpressure. The
+// channel is closed once every chunk has been sent, after the first error,
+// or when ctx is cancelled.
+func (r *Reader) StreamChunks(ctx context.Context, opts ...ReadOption) (<-chan ChunkResult, error) {
+	if r.viewErr != nil {
+		return nil, r.viewErr
+	}
+
+	results := make(chan ChunkResult)
+	go func() {
+		defer close(results)
+
+		send := func(res ChunkResult) bool {
+			if ctx.Err() != nil {
+				return false
+			}
+			select {
+			case results <- res:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		r.EachChunk(ctx, func(coords []int) error {
+			start, shape := r.chunkRegion(coords)
+			data, err := r.ReadRegion(ctx, start, shape, opts...)
+			if err != nil {
+				send(ChunkResult{Coords: coords, Start: start, Shape: shape, Err: err})
+				return errStreamStopped
+			}
+			if !send(ChunkResult{Coords: coords, Start: start, Shape: shape, Data: data}) {
+				return errStreamStopped
+			}
+			return nil
+		})
+	}()
+	return results, nil
+}
+
+// chunkRegion returns the region, in view coordinates, that the stored chunk
+// at coords covers.
+func (r *Reader) chunkRegion(coords []int) (start, shape []int) {
+	start = make([]int, len(coords))
+	shape = make([]int, len(coords))
+	for j := range coords {
+		i := r.storageAxis(j)
+		lo := coords[i] * r.meta.Chunks[i]
+		hi := min(lo+r.meta.Chunks[i], r.meta.Shape[i])
+		if r.isFlipped(i) {
+			lo, hi = r.meta.Shape[i]-hi, r.meta.Shape[i]-lo
+		}
+		start[j] = lo
+		shape[j] = hi - lo
+	}
+	return start, shape
+}
@@ -0,0 +1,112 @@
+package zarr_test
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_StreamChunks(t *testing.T) {
+	// A 5x5 array in 2x2 chunks, so the last row and column of chunks are
+	// clipped at the edge.
+	fb, values := gzipArray(t, 5, 2)
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	var want float64
+	for _, v := range values {
+		want += float64(v)
+	}
+
+	for name, view := range map[string]*zarr.Reader{
+		"plain":   reader,
+		"flipped": reader.Flip([]int{1}).Transpose([]int{1, 0}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			results, err := view.StreamChunks(ctx)
+			if err != nil {
+				t.Fatalf("StreamChunks failed: %v", err)
+			}
+			full, err := view.ReadFull(ctx)
+			if err != nil {
+				t.Fatalf("ReadFull failed: %v", err)
+			}
+			all := decodeFloat32(full)
+
+			var sum float64
+			chunks := 0
+			for res := range results {
+				if res.Err != nil {
+					t.Fatalf("chunk %v failed: %v", res.Coords, res.Err)
+				}
+				chunks++
+				got := decodeFloat32(res.Data)
+				if len(got) != res.Shape[0]*res.Shape[1] {
+					t.Fatalf("chunk %v has %d values for shape %v", res.Coords, len(got), res.Shape)
+				}
+				for k, v := range got {
+					i, j := res.Start[0]+k/res.Shape[1], res.Start[1]+k%res.Shape[1]
+					if all[i*5+j] != v {
+						t.Fatalf("chunk %v: value at (%d, %d) is %v, ReadFull has %v", res.Coords, i, j, v, all[i*5+j])
+					}
+					sum += float64(v)
+				}
+			}
+			if chunks != 9 {
+				t.Errorf("expected 9 chunks, got %d", chunks)
+			}
+			if sum != want {
+				t.Errorf("expected a running sum of %v, got %v", want, sum)
+			}
+		})
+	}
+}
+
+func TestReader_StreamChunksError(t *testing.T) {
+	fb, _ := gzipArray(t, 4, 2)
+	fb.put("0.1", []byte("not gzip"))
+	reader := openFake(t, fb)
+
+	results, err := reader.StreamChunks(context.Background())
+	if err != nil {
+		t.Fatalf("StreamChunks failed: %v", err)
+	}
+	var got []string
+	for res := range results {
+		got = append(got, fmt.Sprint(res.Coords, res.Err != nil))
+	}
+	if want := []string{"[0 0] false", "[0 1] true"}; !slices.Equal(got, want) {
+		t.Errorf("expected the stream to stop after the first error, got %v", got)
+	}
+}
+
+func ExampleReader_StreamChunks() {
+	ctx := context.Background()
+	reader, err := zarr.NewReader(ctx, "file:///path/to/array.zarr")
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	results, err := reader.StreamChunks(ctx)
+	if err != nil {
+		return
+	}
+	var sum float64
+	for res := range results {
+		if res.Err != nil {
+			fmt.Println(res.Err)
+			return
+		}
+		// Assuming a "<f4" array.
+		for i := 0; i+4 <= len(res.Data); i += 4 {
+			sum += float64(math.Float32frombits(binary.LittleEndian.Uint32(res.Data[i:])))
+		}
+	}
+	fmt.Println(sum)
+}
@@ -0,0 +1,86 @@
+package zarr
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// stringDType parses numpy's fixed-width string dtypes: "|S<n>" for n-byte
+// NUL-padded byte strings and "<U<n>" or ">U<n>" for n UTF-32 code units.
+// It returns the kind ('S' or 'U') and the item size in bytes, which is zero
+// for the degenerate "|S0" and "<U0" dtypes.
+func stringDType(s string) (byte, int, bool) {
+	if len(s) < 3 || (s[1] != 'S' && s[1] != 'U') {
+		return 0, 0, false
+	}
+	n, err := strconv.Atoi(s[2:])
+	if err != nil || n < 0 {
+		return 0, 0, false
+	}
+	if s[1] == 'U' {
+		return 'U', 4 * n, true
+	}
+	return 'S', n, true
+}
+
+// ReadStrings reads an array with a fixed-width string dtype ("|S<n>",
+// "<U<n>" or ">U<n>") and returns its elements in C order of the reader's
+// view, with trailing NUL padding removed. Zero-width dtypes such as "|S0"
+// hold only empty strings; their element count comes from the shape and no
+// chunks are read. WithMaxReadBytes counts a string header per element on
+// top of the item size.
+func (r *Reader) ReadStrings(ctx context.Context, opts ...ReadOption) ([]string, error) {
+	if r.viewErr != nil {
+		return nil, r.viewErr
+	}
+	kind, itemSize, ok := stringDType(r.meta.DType)
+	if !ok {
+		return nil, fmt.Errorf("ReadStrings requires a fixed-width string dtype, got %s", r.meta.DType)
+	}
+
+	// Each element costs a string header besides its contents, which keeps
+	// the limit meaningful for zero-width dtypes.
+	elemSize := int(unsafe.Sizeof("")) + itemSize
+	size, err := byteSize(r.Shape(), elemSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkReadSize(size); err != nil {
+		return nil, err
+	}
+	out := make([]string, size/elemSize)
+	if itemSize == 0 {
+		return out, nil
+	}
+
+	data, err := r.WithRawDType(itemSize).ReadFull(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if r.meta.DType[0] == '>' {
+		order = binary.BigEndian
+	}
+	for i := range out {
+		elem := data[i*itemSize : (i+1)*itemSize]
+		if kind == 'S' {
+			out[i] = string(bytes.TrimRight(elem, "\x00"))
+			continue
+		}
+		var b []byte
+		for j := 0; j < len(elem); j += 4 {
+			cp := order.Uint32(elem[j:])
+			if cp == 0 {
+				break
+			}
+			b = utf8.AppendRune(b, rune(cp))
+		}
+		out[i] = string(b)
+	}
+	return out, nil
+}
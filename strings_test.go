@@ -0,0 +1,119 @@
+package zarr_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_ReadStringsZeroWidth(t *testing.T) {
+	dir := t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [3, 2],
+		"chunks": [2, 2],
+		"dtype": "|S0",
+		"compressor": null,
+		"fill_value": "",
+		"order": "C"
+	}`, nil)
+	reader := openReader(t, dir)
+
+	got, err := reader.ReadStrings(context.Background())
+	if err != nil {
+		t.Fatalf("ReadStrings failed: %v", err)
+	}
+	if len(got) != 6 {
+		t.Fatalf("expected 6 elements from the shape, got %d", len(got))
+	}
+	for i, s := range got {
+		if s != "" {
+			t.Errorf("element %d: expected an empty string, got %q", i, s)
+		}
+	}
+}
+
+func TestReader_ReadStringsSizeGuard(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [4294967296, 4294967296],
+		"chunks": [1, 1],
+		"dtype": "|S0",
+		"compressor": null,
+		"fill_value": "",
+		"order": "C"
+	}`, nil)
+	reader := openReader(t, dir)
+
+	if _, err := reader.ReadStrings(ctx); !errors.Is(err, zarr.ErrArrayTooLarge) {
+		t.Errorf("expected ErrArrayTooLarge for an overflowing element count, got %v", err)
+	}
+
+	dir = t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [1024, 1024],
+		"chunks": [1024, 1024],
+		"dtype": "|S0",
+		"compressor": null,
+		"fill_value": "",
+		"order": "C"
+	}`, nil)
+	_, err := openReader(t, dir).WithMaxReadBytes(1 << 20).ReadStrings(ctx)
+	if !errors.Is(err, zarr.ErrReadLimitExceeded) {
+		t.Errorf("expected ErrReadLimitExceeded past the read limit, got %v", err)
+	}
+}
+
+func TestReader_ReadStrings(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [3],
+		"chunks": [2],
+		"dtype": "|S3",
+		"compressor": null,
+		"fill_value": "",
+		"order": "C"
+	}`, map[string][]byte{
+		"0": []byte("ab\x00xyz"),
+	})
+	got, err := openReader(t, dir).ReadStrings(ctx)
+	if err != nil {
+		t.Fatalf("ReadStrings failed: %v", err)
+	}
+	if want := []string{"ab", "xyz", ""}; !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	dir = t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [2],
+		"chunks": [2],
+		"dtype": "<U2",
+		"compressor": null,
+		"fill_value": "",
+		"order": "C"
+	}`, map[string][]byte{
+		"0": encodeLE(t, []uint32{'h', 'é', 'z', 0}),
+	})
+	got, err = openReader(t, dir).ReadStrings(ctx)
+	if err != nil {
+		t.Fatalf("ReadStrings failed: %v", err)
+	}
+	if want := []string{"hé", "z"}; !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if _, err := openSequential4x4(t).ReadStrings(ctx); err == nil {
+		t.Error("expected a numeric dtype to be rejected")
+	}
+}
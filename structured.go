@@ -0,0 +1,129 @@
+package zarr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// StructField is one field of a structured dtype.
+type StructField struct {
+	Name string
+	// DType is the numpy-style dtype string of the field's elements.
+	DType string
+	// Shape is the shape of a subarray field, or nil for a scalar field.
+	Shape []int
+	// Offset is the field's byte offset within a record.
+	Offset int
+	// Size is the number of bytes the field takes up in a record.
+	Size int
+}
+
+// StructuredDType describes a numpy structured (record) dtype, which Zarr
+// stores as a JSON list of [name, dtype] or [name, dtype, shape] fields,
+// such as [["x", "<f4"], ["y", "<i4"]]. Fields are packed one after the
+// other, without padding.
+type StructuredDType struct {
+	Fields []StructField
+	// ItemSize is the size in bytes of one record.
+	ItemSize int
+}
+
+// ParseStructuredDType parses the JSON list form of a structured dtype.
+// Fields must have fixed-size numeric, string or opaque dtypes; nested
+// structured fields are not supported.
+func ParseStructuredDType(data []byte) (*StructuredDType, error) {
+	var raw [][]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid structured dtype: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("structured dtype has no fields")
+	}
+
+	d := &StructuredDType{}
+	seen := make(map[string]bool, len(raw))
+	for i, parts := range raw {
+		if len(parts) != 2 && len(parts) != 3 {
+			return nil, fmt.Errorf("structured dtype field %d has %d entries, expected 2 or 3", i, len(parts))
+		}
+		var f StructField
+		if err := json.Unmarshal(parts[0], &f.Name); err != nil || f.Name == "" {
+			return nil, fmt.Errorf("structured dtype field %d has an invalid name %s", i, parts[0])
+		}
+		if seen[f.Name] {
+			return nil, fmt.Errorf("duplicate field %q in structured dtype", f.Name)
+		}
+		seen[f.Name] = true
+		if err := json.Unmarshal(parts[1], &f.DType); err != nil {
+			return nil, fmt.Errorf("field %q: nested structured dtypes are not supported", f.Name)
+		}
+		size, err := fieldItemSize(f.DType)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", f.Name, err)
+		}
+		if len(parts) == 3 {
+			if err := json.Unmarshal(parts[2], &f.Shape); err != nil {
+				return nil, fmt.Errorf("field %q has an invalid shape %s", f.Name, parts[2])
+			}
+		}
+		if size, err = byteSize(f.Shape, size); err != nil {
+			return nil, fmt.Errorf("field %q: %w", f.Name, err)
+		}
+		f.Offset = d.ItemSize
+		f.Size = size
+		d.ItemSize += size
+		d.Fields = append(d.Fields, f)
+	}
+	return d, nil
+}
+
+// Field returns the field with the given name.
+func (d *StructuredDType) Field(name string) (StructField, bool) {
+	for _, f := range d.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return StructField{}, false
+}
+
+// MarshalJSON encodes the dtype in the list form ParseStructuredDType reads.
+func (d *StructuredDType) MarshalJSON() ([]byte, error) {
+	fields := make([][]any, len(d.Fields))
+	for i, f := range d.Fields {
+		fields[i] = []any{f.Name, f.DType}
+		if f.Shape != nil {
+			fields[i] = append(fields[i], f.Shape)
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// fieldItemSize returns the element size of a structured dtype field's
+// dtype.
+func fieldItemSize(dtype string) (int, error) {
+	if err := validateDType(dtype); err != nil {
+		return 0, err
+	}
+	if _, size, ok := stringDType(dtype); ok {
+		return size, nil
+	}
+	if len(dtype) > 2 && dtype[1] == 'V' {
+		if n, err := strconv.Atoi(dtype[2:]); err == nil && n >= 0 {
+			return n, nil
+		}
+	}
+	_, size, err := ParseDType(dtype)
+	if err != nil {
+		return 0, fmt.Errorf("unsupported dtype %s", dtype)
+	}
+	return size, nil
+}
+
+// isJSONList reports whether data holds a JSON array.
+func isJSONList(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '['
+}
@@ -0,0 +1,110 @@
+package zarr_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+// record encodes one element of the [["x", "<f4"], ["y", "<i4"]] dtype.
+func record(x float32, y int32) []byte {
+	buf, _ := binary.Append(nil, binary.LittleEndian, struct {
+		X float32
+		Y int32
+	}{x, y})
+	return buf
+}
+
+func TestReader_StructuredDType(t *testing.T) {
+	dir := t.TempDir()
+	records := [][]byte{record(1.5, -1), record(2.5, 7), record(-3, 1<<20)}
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [3],
+		"chunks": [2],
+		"dtype": [["x", "<f4"], ["y", "<i4"]],
+		"compressor": null,
+		"fill_value": null,
+		"order": "C"
+	}`, map[string][]byte{
+		"0": bytes.Join(records[:2], nil),
+		"1": bytes.Join([][]byte{records[2], make([]byte, 8)}, nil),
+	})
+	reader := openReader(t, dir)
+	ctx := context.Background()
+
+	meta := reader.Metadata()
+	if meta.DType != "|V8" || meta.Structured == nil {
+		t.Fatalf("expected a |V8 structured dtype, got %q, %v", meta.DType, meta.Structured)
+	}
+	want := []zarr.StructField{
+		{Name: "x", DType: "<f4", Offset: 0, Size: 4},
+		{Name: "y", DType: "<i4", Offset: 4, Size: 4},
+	}
+	if !reflect.DeepEqual(meta.Structured.Fields, want) || meta.Structured.ItemSize != 8 {
+		t.Errorf("expected fields %+v of 8 bytes, got %+v of %d", want, meta.Structured.Fields, meta.Structured.ItemSize)
+	}
+
+	full, err := reader.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+	if !bytes.Equal(full, bytes.Join(records, nil)) {
+		t.Errorf("expected the raw records, got %v", full)
+	}
+	region, err := reader.ReadRegion(ctx, []int{1}, []int{2})
+	if err != nil {
+		t.Fatalf("ReadRegion failed: %v", err)
+	}
+	if !bytes.Equal(region, bytes.Join(records[1:], nil)) {
+		t.Errorf("expected records 1 and 2, got %v", region)
+	}
+	if _, err := zarr.ReadFullAs[float32](ctx, reader); err == nil {
+		t.Error("expected ReadFullAs to reject a structured dtype")
+	}
+
+	data, err := meta.MarshalZArray()
+	if err != nil {
+		t.Fatalf("MarshalZArray failed: %v", err)
+	}
+	loaded, err := zarr.LoadMetadata(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadMetadata of marshaled metadata failed: %v\n%s", err, data)
+	}
+	if !reflect.DeepEqual(loaded.Structured, meta.Structured) {
+		t.Errorf("structured dtype changed in the round trip: %s", data)
+	}
+}
+
+func TestParseStructuredDType(t *testing.T) {
+	d, err := zarr.ParseStructuredDType([]byte(`[["id", "<u2"], ["name", "|S5"], ["pos", ">f8", [2, 3]]]`))
+	if err != nil {
+		t.Fatalf("ParseStructuredDType failed: %v", err)
+	}
+	pos, ok := d.Field("pos")
+	if !ok || pos.Offset != 7 || pos.Size != 48 || !reflect.DeepEqual(pos.Shape, []int{2, 3}) {
+		t.Errorf("unexpected pos field %+v", pos)
+	}
+	if d.ItemSize != 55 {
+		t.Errorf("expected an item size of 55, got %d", d.ItemSize)
+	}
+
+	for _, tc := range []struct{ dtype, errPart string }{
+		{`[]`, "no fields"},
+		{`[["x"]]`, "expected 2 or 3"},
+		{`[["x", "<f4"], ["x", "<i4"]]`, "duplicate"},
+		{`[["x", [["a", "<f4"]]]]`, "nested"},
+		{`[["x", "<x4"]]`, "unsupported"},
+		{`[["x", "f4"]]`, "byte order"},
+		{`[["", "<f4"]]`, "invalid name"},
+	} {
+		if _, err := zarr.ParseStructuredDType([]byte(tc.dtype)); err == nil || !strings.Contains(err.Error(), tc.errPart) {
+			t.Errorf("%s: expected an error containing %q, got %v", tc.dtype, tc.errPart, err)
+		}
+	}
+}
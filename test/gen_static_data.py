@@ -47,6 +47,9 @@ def main():
     # 6. Blosc ZSTD with BitShuffle
     create_variation("blosc_zstd_bitshuffle", compressor=numcodecs.Blosc(cname='zstd', clevel=1, shuffle=numcodecs.Blosc.BITSHUFFLE))
 
+    # 7. BZ2
+    create_variation("bz2", compressor=numcodecs.BZ2(level=1))
+
 
 if __name__ == "__main__":
     main()
\ No newline at end of file
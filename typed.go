@@ -0,0 +1,85 @@
+package zarr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Numeric is the set of Go element types a numeric dtype decodes to.
+type Numeric interface {
+	~int8 | ~int16 | ~int32 | ~int64 |
+		~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 | ~complex64 | ~complex128
+}
+
+// ReadFullAs reads the whole array like ReadFull and decodes it into a slice
+// of T. T must be the Go type of the array's dtype, e.g. float32 for "<f4"
+// or ">f4"; big-endian dtypes are decoded correctly since the Reader always
+// returns little-endian data.
+func ReadFullAs[T Numeric](ctx context.Context, r *Reader, opts ...ReadOption) ([]T, error) {
+	if err := checkElemType[T](r); err != nil {
+		return nil, err
+	}
+	data, err := r.ReadFull(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAs[T](data)
+}
+
+// ReadFullComplex64 reads a c8 array, stored as interleaved float32 real
+// and imaginary parts, into complex64 values.
+func (r *Reader) ReadFullComplex64(ctx context.Context, opts ...ReadOption) ([]complex64, error) {
+	return ReadFullAs[complex64](ctx, r, opts...)
+}
+
+// ReadFullComplex128 reads a c16 array, stored as interleaved float64 real
+// and imaginary parts, into complex128 values.
+func (r *Reader) ReadFullComplex128(ctx context.Context, opts ...ReadOption) ([]complex128, error) {
+	return ReadFullAs[complex128](ctx, r, opts...)
+}
+
+// ReadRegionAs reads a region like ReadRegion and decodes it into a slice of
+// T, which must be the Go type of the array's dtype.
+func ReadRegionAs[T Numeric](ctx context.Context, r *Reader, start, shape []int, opts ...ReadOption) ([]T, error) {
+	if err := checkElemType[T](r); err != nil {
+		return nil, err
+	}
+	data, err := r.ReadRegion(ctx, start, shape, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAs[T](data)
+}
+
+// checkElemType reports an error unless T has the memory layout of the
+// reader's dtype.
+func checkElemType[T Numeric](r *Reader) error {
+	if r.rawItemSize > 0 {
+		return fmt.Errorf("raw dtype views cannot be decoded as %s", reflect.TypeFor[T]())
+	}
+	return checkDType[T](r.meta.DType)
+}
+
+// checkDType reports an error unless T is the Go type of dtype.
+func checkDType[T Numeric](dtype string) error {
+	name, _, err := ParseDType(dtype)
+	if err != nil {
+		return fmt.Errorf("invalid dtype: %w", err)
+	}
+	want, ok := goTypes[name]
+	if typ := reflect.TypeFor[T](); !ok || typ.Kind() != want.Kind() {
+		return fmt.Errorf("dtype %s cannot be decoded as %s", dtype, typ)
+	}
+	return nil
+}
+
+// decodeAs decodes little-endian element bytes into a new slice of T.
+func decodeAs[T Numeric](data []byte) ([]T, error) {
+	slice, err := decodeSlice(data, reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	return slice.Interface().([]T), nil
+}
@@ -0,0 +1,173 @@
+package zarr_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReadFullAs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("float32", func(t *testing.T) {
+		reader := openSequential4x4(t)
+
+		got, err := zarr.ReadFullAs[float32](ctx, reader)
+		if err != nil {
+			t.Fatalf("ReadFullAs failed: %v", err)
+		}
+		want := []float32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+		if !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+
+		region, err := zarr.ReadRegionAs[float32](ctx, reader, []int{1, 1}, []int{2, 2})
+		if err != nil {
+			t.Fatalf("ReadRegionAs failed: %v", err)
+		}
+		if want := []float32{5, 6, 9, 10}; !slices.Equal(region, want) {
+			t.Errorf("expected %v, got %v", want, region)
+		}
+
+		if _, err := zarr.ReadFullAs[float64](ctx, reader); err == nil {
+			t.Error("expected float64 to be rejected for a <f4 array")
+		}
+		if _, err := zarr.ReadFullAs[int32](ctx, reader); err == nil {
+			t.Error("expected int32 to be rejected for a <f4 array")
+		}
+	})
+
+	t.Run("int64", func(t *testing.T) {
+		dir := t.TempDir()
+		writeArray(t, dir, int64Vector6, map[string][]byte{
+			"0": encodeLE(t, []int64{-1, 2, -3, 4}),
+			"1": encodeLE(t, []int64{1 << 40, -(1 << 40), 0, 0}),
+		})
+		reader := openReader(t, dir)
+
+		got, err := zarr.ReadFullAs[int64](ctx, reader)
+		if err != nil {
+			t.Fatalf("ReadFullAs failed: %v", err)
+		}
+		if want := []int64{-1, 2, -3, 4, 1 << 40, -(1 << 40)}; !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+		if _, err := zarr.ReadFullAs[uint64](ctx, reader); err == nil {
+			t.Error("expected uint64 to be rejected for a <i8 array")
+		}
+	})
+
+	t.Run(">i8", func(t *testing.T) {
+		dir := t.TempDir()
+		writeArray(t, dir, `{
+			"zarr_format": 2,
+			"shape": [3],
+			"chunks": [3],
+			"dtype": ">i8",
+			"compressor": null,
+			"fill_value": 0,
+			"order": "C"
+		}`, map[string][]byte{
+			"0": encodeBE(t, []int64{-7, 1 << 50, 42}),
+		})
+		reader := openReader(t, dir)
+
+		got, err := zarr.ReadFullAs[int64](ctx, reader)
+		if err != nil {
+			t.Fatalf("ReadFullAs failed: %v", err)
+		}
+		if want := []int64{-7, 1 << 50, 42}; !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+}
+
+func TestReader_ReadFullComplex(t *testing.T) {
+	ctx := context.Background()
+	if zarr.Complex64.Size() != 8 || zarr.Complex128.Size() != 16 {
+		t.Fatalf("expected complex dtype sizes 8 and 16, got %d and %d", zarr.Complex64.Size(), zarr.Complex128.Size())
+	}
+	zarray := func(dtype string) string {
+		return `{
+			"zarr_format": 2,
+			"shape": [3],
+			"chunks": [2],
+			"dtype": "` + dtype + `",
+			"compressor": null,
+			"fill_value": [1.0, -1.0],
+			"order": "C"
+		}`
+	}
+
+	for _, tc := range []struct {
+		dtype  string
+		encode func(*testing.T, any) []byte
+	}{
+		{"<c8", encodeLE},
+		{">c8", encodeBE},
+	} {
+		t.Run(tc.dtype, func(t *testing.T) {
+			dir := t.TempDir()
+			writeArray(t, dir, zarray(tc.dtype), map[string][]byte{
+				"0": tc.encode(t, []float32{1.5, -2, 0, 3.25}),
+			})
+			reader := openReader(t, dir)
+			got, err := reader.ReadFullComplex64(ctx)
+			if err != nil {
+				t.Fatalf("ReadFullComplex64 failed: %v", err)
+			}
+			want := []complex64{complex(1.5, -2), complex(0, 3.25), complex(1, -1)}
+			if !slices.Equal(got, want) {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+
+			res, err := reader.ReadFullResult(ctx)
+			if err != nil {
+				t.Fatalf("ReadFullResult failed: %v", err)
+			}
+			if got, err := res.Complex64(); err != nil || !slices.Equal(got, want) {
+				t.Errorf("expected Complex64 to return %v, got %v, %v", want, got, err)
+			}
+			if _, err := res.Complex128(); err == nil {
+				t.Error("expected Complex128 to reject a c8 result")
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		dtype  string
+		encode func(*testing.T, any) []byte
+	}{
+		{"<c16", encodeLE},
+		{">c16", encodeBE},
+	} {
+		t.Run(tc.dtype, func(t *testing.T) {
+			dir := t.TempDir()
+			writeArray(t, dir, zarray(tc.dtype), map[string][]byte{
+				"0": tc.encode(t, []float64{1e300, -0.5, 7, 8}),
+			})
+			reader := openReader(t, dir)
+			got, err := reader.ReadFullComplex128(ctx)
+			if err != nil {
+				t.Fatalf("ReadFullComplex128 failed: %v", err)
+			}
+			want := []complex128{complex(1e300, -0.5), complex(7, 8), complex(1, -1)}
+			if !slices.Equal(got, want) {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+			if _, err := reader.ReadFullComplex64(ctx); err == nil {
+				t.Error("expected ReadFullComplex64 to reject a c16 array")
+			}
+
+			res, err := reader.ReadFullResult(ctx)
+			if err != nil {
+				t.Fatalf("ReadFullResult failed: %v", err)
+			}
+			if got, err := res.Complex128(); err != nil || !slices.Equal(got, want) {
+				t.Errorf("expected Complex128 to return %v, got %v, %v", want, got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package zarr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+)
+
+// VerifyEqual compares two arrays element by element and reports whether
+// they match to within epsilon, e.g. to validate a re-chunked or
+// re-compressed copy. The arrays may differ in chunking, compressor and byte
+// order, but must have the same shape and element type. They are read one
+// block of a's chunk shape at a time, so memory use stays bounded. When they
+// differ, the coordinates of the first mismatch found are returned; blocks
+// are visited in C order. NaNs compare equal to each other.
+func VerifyEqual(ctx context.Context, a, b *Reader, epsilon float64) (bool, []int, error) {
+	shape := a.Shape()
+	if !slices.Equal(shape, b.Shape()) {
+		return false, nil, fmt.Errorf("shapes differ: %v and %v", shape, b.Shape())
+	}
+	nameA, _, err := ParseDType(a.meta.DType)
+	if err != nil {
+		return false, nil, fmt.Errorf("invalid dtype: %w", err)
+	}
+	nameB, _, err := ParseDType(b.meta.DType)
+	if err != nil {
+		return false, nil, fmt.Errorf("invalid dtype: %w", err)
+	}
+	if nameA != nameB {
+		return false, nil, fmt.Errorf("dtypes differ: %s and %s", a.meta.DType, b.meta.DType)
+	}
+
+	if len(shape) == 0 {
+		return verifyBlock(ctx, a, b, nil, nil, epsilon)
+	}
+
+	block := make([]int, len(shape))
+	first := make([]int, len(shape))
+	last := make([]int, len(shape))
+	for i := range shape {
+		block[i] = a.meta.Chunks[a.storageAxis(i)]
+		last[i] = (shape[i] - 1) / block[i]
+	}
+
+	var mismatch []int
+	err = forEachChunk(first, last, ChunkOrderC, func(coords []int) error {
+		start := make([]int, len(shape))
+		size := make([]int, len(shape))
+		for i, c := range coords {
+			start[i] = c * block[i]
+			size[i] = min(block[i], shape[i]-start[i])
+		}
+		equal, at, err := verifyBlock(ctx, a, b, start, size, epsilon)
+		if err != nil {
+			return err
+		}
+		if !equal {
+			mismatch = at
+			return errStopIteration
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopIteration) {
+		return false, nil, err
+	}
+	return mismatch == nil, mismatch, nil
+}
+
+// errStopIteration ends a forEachChunk loop early without reporting an
+// error.
+var errStopIteration = errors.New("stop iteration")
+
+// verifyBlock compares one region of a and b; nil start and shape mean the
+// whole of a 0-dimensional array.
+func verifyBlock(ctx context.Context, a, b *Reader, start, shape []int, epsilon float64) (bool, []int, error) {
+	if start == nil {
+		start, shape = []int{}, []int{}
+	}
+	va, _, err := a.ReadRegionReflect(ctx, start, shape)
+	if err != nil {
+		return false, nil, err
+	}
+	vb, _, err := b.ReadRegionReflect(ctx, start, shape)
+	if err != nil {
+		return false, nil, err
+	}
+
+	for n := 0; n < va.Len(); n++ {
+		equal, err := elementsClose(va.Index(n), vb.Index(n), epsilon)
+		if err != nil {
+			return false, nil, err
+		}
+		if !equal {
+			// Convert the flat index within the block to array coordinates.
+			at := make([]int, len(shape))
+			rem := n
+			for i := len(shape) - 1; i >= 0; i-- {
+				at[i] = start[i] + rem%shape[i]
+				rem /= shape[i]
+			}
+			return false, at, nil
+		}
+	}
+	return true, nil, nil
+}
+
+// elementsClose compares two decoded elements of the same type.
+func elementsClose(x, y reflect.Value, epsilon float64) (bool, error) {
+	if x.Kind() == reflect.Complex64 || x.Kind() == reflect.Complex128 {
+		cx, cy := x.Complex(), y.Complex()
+		return floatsClose(real(cx), real(cy), epsilon) && floatsClose(imag(cx), imag(cy), epsilon), nil
+	}
+	switch x.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Exact first, so that large 64-bit values are not rounded.
+		if x.Int() == y.Int() {
+			return true, nil
+		}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if x.Uint() == y.Uint() {
+			return true, nil
+		}
+	}
+	fx, err := elementFloat(x)
+	if err != nil {
+		return false, err
+	}
+	fy, err := elementFloat(y)
+	if err != nil {
+		return false, err
+	}
+	return floatsClose(fx, fy, epsilon), nil
+}
+
+func floatsClose(x, y, epsilon float64) bool {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return math.IsNaN(x) && math.IsNaN(y)
+	}
+	return x == y || math.Abs(x-y) <= epsilon
+}
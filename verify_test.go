@@ -0,0 +1,62 @@
+package zarr_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestVerifyEqual(t *testing.T) {
+	shape := []int{5, 7}
+	values := make([]float32, 35)
+	for i := range values {
+		values[i] = float32(i)
+	}
+	original := openSequential(t, shape, []int{2, 3})
+	rechunked := openSequential(t, shape, []int{4, 4})
+	ctx := context.Background()
+
+	equal, at, err := zarr.VerifyEqual(ctx, original, rechunked, 0)
+	if err != nil {
+		t.Fatalf("VerifyEqual failed: %v", err)
+	}
+	if !equal || at != nil {
+		t.Errorf("expected a re-chunked copy to be equal, got mismatch at %v", at)
+	}
+
+	// Perturb one element of another re-chunked copy.
+	values[3*7+5] += 0.5
+	dir := t.TempDir()
+	writeFloat32Array(t, dir, `{
+		"zarr_format": 2,
+		"shape": [5, 7],
+		"chunks": [5, 2],
+		"dtype": "<f4",
+		"compressor": null,
+		"fill_value": 0.0,
+		"order": "C"
+	}`, chunkFloat32(shape, []int{5, 2}, values))
+	perturbed := openReader(t, dir)
+
+	equal, at, err = zarr.VerifyEqual(ctx, original, perturbed, 0.1)
+	if err != nil {
+		t.Fatalf("VerifyEqual failed: %v", err)
+	}
+	if equal || !slices.Equal(at, []int{3, 5}) {
+		t.Errorf("expected a mismatch at [3 5], got equal=%v at %v", equal, at)
+	}
+
+	equal, _, err = zarr.VerifyEqual(ctx, original, perturbed, 1)
+	if err != nil {
+		t.Fatalf("VerifyEqual failed: %v", err)
+	}
+	if !equal {
+		t.Error("expected the arrays to match within an epsilon of 1")
+	}
+
+	if _, _, err := zarr.VerifyEqual(ctx, original, openSequential4x4(t), 0); err == nil {
+		t.Error("expected an error for arrays of different shapes")
+	}
+}
@@ -0,0 +1,213 @@
+package zarr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// view returns a shallow copy of the reader sharing its bucket and metadata.
+// Views do not hold a reference to the bucket; closing a view is a no-op and
+// the Reader it was derived from must stay open while the view is used.
+func (r *Reader) view() *Reader {
+	v := *r
+	v.isView = true
+	v.perm = append([]int(nil), r.perm...)
+	v.flipped = append([]bool(nil), r.flipped...)
+	return &v
+}
+
+// Flip returns a view of the array with the element order reversed along the
+// given axes, so that ReadFull and ReadRegion yield flipped data. Regions
+// passed to the view are expressed in flipped coordinates. Invalid axes are
+// reported by the view's read methods, as for the other view constructors.
+func (r *Reader) Flip(axes []int) *Reader {
+	v := r.view()
+	if v.flipped == nil {
+		v.flipped = make([]bool, len(r.meta.Shape))
+	}
+	for _, axis := range axes {
+		if axis < 0 || axis >= len(r.meta.Shape) {
+			v.viewErr = fmt.Errorf("flip axis %d out of range for rank %d", axis, len(r.meta.Shape))
+			return v
+		}
+		storage := v.storageAxis(axis)
+		v.flipped[storage] = !v.flipped[storage]
+	}
+	return v
+}
+
+// Transpose returns a view presenting the axes in the order given by perm:
+// axis i of the view is axis perm[i] of the reader it is called on. All reads
+// on the view, including the regions passed to them, use the permuted axis
+// order, e.g. Transpose([]int{2, 1, 0}) turns a ZYX array into XYZ.
+func (r *Reader) Transpose(perm []int) *Reader {
+	v := r.view()
+	rank := len(r.meta.Shape)
+	if len(perm) != rank {
+		v.viewErr = fmt.Errorf("transpose permutation %v does not match rank %d", perm, rank)
+		return v
+	}
+	seen := make([]bool, rank)
+	v.perm = make([]int, rank)
+	for i, axis := range perm {
+		if axis < 0 || axis >= rank || seen[axis] {
+			v.viewErr = fmt.Errorf("invalid transpose permutation %v", perm)
+			return v
+		}
+		seen[axis] = true
+		v.perm[i] = r.storageAxis(axis)
+	}
+	return v
+}
+
+// Shape returns the shape of the array as presented by the reader, which
+// differs from Metadata().Shape for transposed views.
+func (r *Reader) Shape() []int {
+	shape := make([]int, len(r.meta.Shape))
+	for i := range shape {
+		shape[i] = r.meta.Shape[r.storageAxis(i)]
+	}
+	return shape
+}
+
+// storageAxis maps an axis of the view to the corresponding stored axis.
+func (r *Reader) storageAxis(axis int) int {
+	if r.perm == nil {
+		return axis
+	}
+	return r.perm[axis]
+}
+
+// WithRawDType returns a view that treats elements as opaque records of
+// itemSize bytes, regardless of the dtype declared in .zarray. This lets
+// ReadChunk, ReadRegion and ReadFull work byte-wise on dtypes the package
+// does not understand yet.
+func (r *Reader) WithRawDType(itemSize int) *Reader {
+	v := r.view()
+	if itemSize <= 0 {
+		v.viewErr = fmt.Errorf("raw item size must be positive, got %d", itemSize)
+		return v
+	}
+	v.rawItemSize = itemSize
+	return v
+}
+
+// WithChunkEncoding returns a view that locates chunks with the given key
+// encoding instead of the one implied by .zarray, for stores with unusual
+// key layouts. The view starts without a primed chunk index.
+func (r *Reader) WithChunkEncoding(enc ChunkEncoding) *Reader {
+	v := r.view()
+	v.encoding = enc
+	v.chunkIndex = nil
+	return v
+}
+
+// ChunkEncoding returns the key encoding the reader uses for chunks.
+func (r *Reader) ChunkEncoding() ChunkEncoding {
+	return r.encoding
+}
+
+// WithMaxReadBytes returns a view whose ReadFull and ReadRegion calls fail
+// with ErrReadLimitExceeded, before allocating, when their output would be
+// larger than limit bytes. A limit of zero or less disables the guard.
+func (r *Reader) WithMaxReadBytes(limit int64) *Reader {
+	v := r.view()
+	v.maxReadBytes = limit
+	return v
+}
+
+// checkReadSize enforces the WithMaxReadBytes limit for an output of n bytes.
+func (r *Reader) checkReadSize(n int) error {
+	if r.maxReadBytes > 0 && int64(n) > r.maxReadBytes {
+		return fmt.Errorf("%w: %d bytes requested, limit is %d; read a smaller region or stream the array chunk by chunk instead",
+			ErrReadLimitExceeded, n, r.maxReadBytes)
+	}
+	return nil
+}
+
+// WithMaxChunksPerRead returns a view whose ReadFull and ReadRegion calls
+// fail with ErrTooManyChunks, before any chunk is requested, when they would
+// touch more than limit chunks. A limit of zero or less disables the guard.
+func (r *Reader) WithMaxChunksPerRead(limit int) *Reader {
+	v := r.view()
+	v.maxChunks = limit
+	return v
+}
+
+// checkChunkCount enforces the WithMaxChunksPerRead limit for a read
+// touching perAxis[i] chunks along each storage axis i.
+func (r *Reader) checkChunkCount(perAxis []int) error {
+	if r.maxChunks <= 0 {
+		return nil
+	}
+	n := 1
+	for _, c := range perAxis {
+		n *= c
+	}
+	if n > r.maxChunks {
+		return fmt.Errorf("%w: %d chunks requested, limit is %d", ErrTooManyChunks, n, r.maxChunks)
+	}
+	return nil
+}
+
+// isFlipped reports whether the view reverses the given axis.
+func (r *Reader) isFlipped(axis int) bool {
+	return axis < len(r.flipped) && r.flipped[axis]
+}
+
+// hasViewTransform reports whether reads need to go through the view-aware
+// region assembly rather than the plain chunk stitching of ReadFull.
+func (r *Reader) hasViewTransform() bool {
+	for i, axis := range r.perm {
+		if i != axis {
+			return true
+		}
+	}
+	for _, f := range r.flipped {
+		if f {
+			return true
+		}
+	}
+	return false
+}
+
+// WithElementTransform returns a view whose float64 reads, ReadRegionScaled
+// and LazyArray.At, pass every value through fn, e.g. to convert units or
+// mask values on the fly. It runs after CF scale_factor/add_offset decoding
+// and is not applied to fill values, which ReadRegionScaled reports as NaN.
+// Calling it again on the view composes the functions, the earlier one
+// running first.
+func (r *Reader) WithElementTransform(fn func(v float64) float64) *Reader {
+	v := r.view()
+	if prev := r.transform; prev != nil && fn != nil {
+		v.transform = func(x float64) float64 { return fn(prev(x)) }
+	} else if fn != nil {
+		v.transform = fn
+	}
+	return v
+}
+
+// transformed applies the element transform, if any, to v.
+func (r *Reader) transformed(v float64) float64 {
+	if r.transform == nil {
+		return v
+	}
+	return r.transform(v)
+}
+
+// WithLogger returns a view that reports chunk fetches, fill substitutions
+// for missing chunks and the codec used for each chunk to logger at debug
+// level. Readers log nothing by default.
+func (r *Reader) WithLogger(logger *slog.Logger) *Reader {
+	v := r.view()
+	v.logger = logger
+	return v
+}
+
+// debug emits a debug event if the reader has a logger.
+func (r *Reader) debug(ctx context.Context, msg string, attrs ...slog.Attr) {
+	if r.logger != nil {
+		r.logger.LogAttrs(ctx, slog.LevelDebug, msg, attrs...)
+	}
+}
@@ -0,0 +1,308 @@
+package zarr_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestReader_Flip(t *testing.T) {
+	reader := openSequential4x4(t)
+	ctx := context.Background()
+
+	flipped := reader.Flip([]int{0})
+
+	data, err := flipped.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull on flipped view failed: %v", err)
+	}
+	expected := []float32{
+		12, 13, 14, 15,
+		8, 9, 10, 11,
+		4, 5, 6, 7,
+		0, 1, 2, 3,
+	}
+	if got := decodeFloat32(data); !reflect.DeepEqual(got, expected) {
+		t.Errorf("flipped ReadFull mismatch.\nExpected: %v\nGot:      %v", expected, got)
+	}
+
+	// Rows 0-1 of the flipped view are rows 3-2 of the array.
+	data, err = flipped.ReadRegion(ctx, []int{0, 1}, []int{2, 2})
+	if err != nil {
+		t.Fatalf("ReadRegion on flipped view failed: %v", err)
+	}
+	expected = []float32{13, 14, 9, 10}
+	if got := decodeFloat32(data); !reflect.DeepEqual(got, expected) {
+		t.Errorf("flipped ReadRegion mismatch.\nExpected: %v\nGot:      %v", expected, got)
+	}
+
+	// Flipping both axes reverses the whole buffer.
+	data, err = reader.Flip([]int{0, 1}).ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull on doubly flipped view failed: %v", err)
+	}
+	got := decodeFloat32(data)
+	for i, v := range got {
+		if v != float32(15-i) {
+			t.Fatalf("doubly flipped mismatch at %d: expected %d, got %v", i, 15-i, v)
+		}
+	}
+
+	// The original reader is unaffected.
+	data, err = reader.ReadRegion(ctx, []int{0, 0}, []int{1, 2})
+	if err != nil {
+		t.Fatalf("ReadRegion on original reader failed: %v", err)
+	}
+	if got := decodeFloat32(data); !reflect.DeepEqual(got, []float32{0, 1}) {
+		t.Errorf("original reader affected by view: %v", got)
+	}
+
+	if _, err := reader.Flip([]int{2}).ReadFull(ctx); err == nil {
+		t.Error("expected an error for an out-of-range flip axis")
+	}
+}
+
+func TestReader_WithRawDType(t *testing.T) {
+	reader := openSequential4x4(t)
+	ctx := context.Background()
+
+	typed, err := reader.ReadRegion(ctx, []int{1, 0}, []int{2, 3})
+	if err != nil {
+		t.Fatalf("ReadRegion failed: %v", err)
+	}
+	raw, err := reader.WithRawDType(4).ReadRegion(ctx, []int{1, 0}, []int{2, 3})
+	if err != nil {
+		t.Fatalf("ReadRegion on raw view failed: %v", err)
+	}
+	if !bytes.Equal(typed, raw) {
+		t.Errorf("raw read differs from typed read.\nExpected: %v\nGot:      %v", typed, raw)
+	}
+
+	value, _, err := reader.WithRawDType(4).ReadRegionReflect(ctx, []int{0, 0}, []int{1, 2})
+	if err != nil {
+		t.Fatalf("ReadRegionReflect on raw view failed: %v", err)
+	}
+	if got := value.Interface().([][4]byte); len(got) != 2 || got[1] != [4]byte{0, 0, 0x80, 0x3f} {
+		t.Errorf("expected two 4-byte records ending in float32(1), got %v", got)
+	}
+
+	if _, err := reader.WithRawDType(0).ReadFull(ctx); err == nil {
+		t.Error("expected an error for a non-positive raw item size")
+	}
+}
+
+func TestReader_WithRawDTypeUnknownDType(t *testing.T) {
+	dir := t.TempDir()
+	writeArray(t, dir, `{
+		"zarr_format": 2,
+		"shape": [2],
+		"chunks": [2],
+		"dtype": "|V3",
+		"compressor": null,
+		"fill_value": null,
+		"order": "C"
+	}`, map[string][]byte{"0": {1, 2, 3, 4, 5, 6}})
+	reader := openReader(t, dir)
+	ctx := context.Background()
+
+	if _, err := reader.ReadFull(ctx); err == nil {
+		t.Fatal("expected ReadFull to reject the unknown dtype")
+	}
+	data, err := reader.WithRawDType(3).ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull on raw view failed: %v", err)
+	}
+	if !bytes.Equal(data, []byte{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("unexpected raw data %v", data)
+	}
+}
+
+func TestReader_Transpose(t *testing.T) {
+	// A 2x3x4 array holding its own C-order flat index, in uneven chunks.
+	reader := openSequential(t, []int{2, 3, 4}, []int{1, 2, 3})
+	ctx := context.Background()
+
+	// Reverse the axes: the view has shape [4, 3, 2] and element (x, y, z)
+	// of the view is element (z, y, x) of the array.
+	transposed := reader.Transpose([]int{2, 1, 0})
+	if got := transposed.Shape(); !reflect.DeepEqual(got, []int{4, 3, 2}) {
+		t.Fatalf("expected transposed shape [4 3 2], got %v", got)
+	}
+
+	data, err := transposed.ReadFull(ctx)
+	if err != nil {
+		t.Fatalf("ReadFull on transposed view failed: %v", err)
+	}
+	got := decodeFloat32(data)
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 3; y++ {
+			for z := 0; z < 2; z++ {
+				want := float32(z*12 + y*4 + x)
+				if v := got[x*6+y*2+z]; v != want {
+					t.Fatalf("mismatch at view (%d, %d, %d): expected %v, got %v", x, y, z, want, v)
+				}
+			}
+		}
+	}
+
+	// Regions are expressed in view coordinates.
+	data, err = transposed.ReadRegion(ctx, []int{1, 2, 1}, []int{2, 1, 1})
+	if err != nil {
+		t.Fatalf("ReadRegion on transposed view failed: %v", err)
+	}
+	if got := decodeFloat32(data); !reflect.DeepEqual(got, []float32{12 + 8 + 1, 12 + 8 + 2}) {
+		t.Errorf("unexpected transposed region %v", got)
+	}
+
+	// Transposes compose, and flips apply to the view's axes.
+	data, err = transposed.Transpose([]int{2, 1, 0}).Flip([]int{2}).ReadRegion(ctx, []int{0, 0, 0}, []int{1, 1, 4})
+	if err != nil {
+		t.Fatalf("ReadRegion on composed view failed: %v", err)
+	}
+	if got := decodeFloat32(data); !reflect.DeepEqual(got, []float32{3, 2, 1, 0}) {
+		t.Errorf("unexpected composed region %v", got)
+	}
+
+	for _, perm := range [][]int{{0, 1}, {0, 1, 1}, {0, 1, 3}} {
+		if _, err := reader.Transpose(perm).ReadFull(ctx); err == nil {
+			t.Errorf("expected an error for permutation %v", perm)
+		}
+	}
+}
+
+func TestReader_WithMaxReadBytes(t *testing.T) {
+	reader := openSequential4x4(t)
+	ctx := context.Background()
+	limited := reader.WithMaxReadBytes(16)
+
+	if _, err := limited.ReadFull(ctx); !errors.Is(err, zarr.ErrReadLimitExceeded) {
+		t.Errorf("ReadFull: expected ErrReadLimitExceeded, got %v", err)
+	}
+	if _, err := limited.ReadRegion(ctx, []int{0, 0}, []int{2, 3}); !errors.Is(err, zarr.ErrReadLimitExceeded) {
+		t.Errorf("ReadRegion: expected ErrReadLimitExceeded, got %v", err)
+	}
+
+	// Reads within the limit still succeed.
+	data, err := limited.ReadRegion(ctx, []int{2, 2}, []int{2, 2})
+	if err != nil {
+		t.Fatalf("ReadRegion within the limit failed: %v", err)
+	}
+	if len(data) != 16 {
+		t.Errorf("expected 16 bytes, got %d", len(data))
+	}
+
+	if _, err := reader.ReadFull(ctx); err != nil {
+		t.Errorf("the unlimited reader was affected by the view: %v", err)
+	}
+}
+
+func TestReader_WithMaxChunksPerRead(t *testing.T) {
+	fb := newFakeBucket(map[string][]byte{
+		".zarray": []byte(`{
+			"zarr_format": 2,
+			"shape": [4, 4],
+			"chunks": [1, 1],
+			"dtype": "<f4",
+			"compressor": null,
+			"fill_value": 0.0,
+			"order": "C"
+		}`),
+	})
+	reader := openFake(t, fb)
+	ctx := context.Background()
+	limited := reader.WithMaxChunksPerRead(4)
+
+	_, err := limited.ReadRegion(ctx, []int{0, 0}, []int{2, 3})
+	if !errors.Is(err, zarr.ErrTooManyChunks) {
+		t.Fatalf("ReadRegion: expected ErrTooManyChunks, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "6 chunks") {
+		t.Errorf("expected the error to report the chunk count, got %q", err)
+	}
+	if _, err := limited.ReadFull(ctx); !errors.Is(err, zarr.ErrTooManyChunks) {
+		t.Errorf("ReadFull: expected ErrTooManyChunks, got %v", err)
+	}
+	if n := fb.readCount(); n != 0 {
+		t.Errorf("expected the guard to fail before any chunk request, got %d reads", n)
+	}
+
+	if _, err := limited.ReadRegion(ctx, []int{1, 1}, []int{2, 2}); err != nil {
+		t.Errorf("ReadRegion within the limit failed: %v", err)
+	}
+}
+
+// recordHandler collects log records for inspection.
+type recordHandler struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *recordHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, r)
+	return nil
+}
+
+// find returns the attributes of records with the given message, keyed by
+// their "key" attribute.
+func (h *recordHandler) find(msg string) map[string]map[string]string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := map[string]map[string]string{}
+	for _, r := range h.records {
+		if r.Message != msg {
+			continue
+		}
+		attrs := map[string]string{}
+		r.Attrs(func(a slog.Attr) bool {
+			attrs[a.Key] = a.Value.String()
+			return true
+		})
+		out[attrs["key"]] = attrs
+	}
+	return out
+}
+
+func TestReader_WithLogger(t *testing.T) {
+	fb, _ := gzipArray(t, 4, 2)
+	fb.Delete(context.Background(), "1.1")
+	reader := openFake(t, fb)
+	ctx := context.Background()
+
+	h := &recordHandler{}
+	if _, err := reader.WithLogger(slog.New(h)).ReadFull(ctx); err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+
+	fetched := h.find("fetched chunk")
+	if len(fetched) != 3 || fetched["0.0"] == nil {
+		t.Errorf("expected fetch events for the three stored chunks, got %v", fetched)
+	}
+	if codec := h.find("decompressing chunk")["0.1"]; codec["codec"] != "gzip" || codec["custom"] != "false" {
+		t.Errorf("expected a gzip codec event for chunk 0.1, got %v", codec)
+	}
+	if fill := h.find("chunk not found, using fill value"); fill["1.1"] == nil || len(fill) != 1 {
+		t.Errorf("expected a fill event for chunk 1.1 only, got %v", fill)
+	}
+
+	// The reader itself stays silent.
+	before := len(h.records)
+	if _, err := reader.ReadFull(ctx); err != nil {
+		t.Fatalf("ReadFull failed: %v", err)
+	}
+	if len(h.records) != before {
+		t.Error("the reader without a logger emitted events")
+	}
+}
@@ -0,0 +1,91 @@
+package zarr
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// walkConcurrency bounds how many groups Walk lists, and how many nodes it
+// opens, at once.
+const walkConcurrency = 8
+
+// Walk visits every array and group below g, at any depth, calling fn with
+// the node's path relative to g and the opened node, a *Reader or a
+// *Group. Listing groups and opening nodes runs concurrently, up to a fixed
+// bound, so nodes are visited in no particular order, but fn is never called
+// concurrently. Each node is closed once fn returns; open it again to keep
+// it. The first error returned by fn or met while walking, or the
+// cancellation of ctx, stops the walk and is returned.
+func (g *Group) Walk(ctx context.Context, fn func(path string, node Node) error) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, walkConcurrency)
+	var mu sync.Mutex
+
+	// acquire takes a slot for a metadata request, unless the walk stops.
+	acquire := func() error {
+		select {
+		case sem <- struct{}{}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	release := func() { <-sem }
+
+	var walkGroup func(group *Group, rel string) error
+	visit := func(rel string, isGroup bool) {
+		eg.Go(func() error {
+			if err := acquire(); err != nil {
+				return err
+			}
+			var node Node
+			var err error
+			if isGroup {
+				node, err = g.OpenGroup(ctx, rel)
+			} else {
+				node, err = g.OpenArray(ctx, rel)
+			}
+			release()
+			if err != nil {
+				return err
+			}
+			defer node.Close()
+
+			mu.Lock()
+			err = fn(rel, node)
+			mu.Unlock()
+			if err != nil || !isGroup {
+				return err
+			}
+			return walkGroup(node.(*Group), rel+"/")
+		})
+	}
+	walkGroup = func(group *Group, rel string) error {
+		if err := acquire(); err != nil {
+			return err
+		}
+		defer release()
+		arrays, err := group.ListArrays(ctx)
+		if err != nil {
+			return err
+		}
+		groups, err := group.ListGroups(ctx)
+		if err != nil {
+			return err
+		}
+		for _, name := range arrays {
+			visit(rel+name, false)
+		}
+		for _, name := range groups {
+			visit(rel+name, true)
+		}
+		return nil
+	}
+
+	eg.Go(func() error {
+		return walkGroup(g, "")
+	})
+	return eg.Wait()
+}
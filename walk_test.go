@@ -0,0 +1,119 @@
+package zarr_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestGroup_Walk(t *testing.T) {
+	// root/
+	//   a              array
+	//   labels/        group
+	//     mask         array
+	//     deep/        group
+	//       x          array
+	//       empty/     group
+	//   stray/         neither array nor group
+	dir := t.TempDir()
+	writeGroup(t, dir)
+	writeFloat32Array(t, filepath.Join(dir, "a"), sequential4x4, nil)
+	writeGroup(t, filepath.Join(dir, "labels"))
+	writeFloat32Array(t, filepath.Join(dir, "labels", "mask"), sequential4x4, nil)
+	writeGroup(t, filepath.Join(dir, "labels", "deep"))
+	writeFloat32Array(t, filepath.Join(dir, "labels", "deep", "x"), vector4, nil)
+	writeGroup(t, filepath.Join(dir, "labels", "deep", "empty"))
+	if err := os.MkdirAll(filepath.Join(dir, "stray"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	root, err := zarr.OpenGroup(ctx, "file:///"+filepath.ToSlash(dir))
+	if err != nil {
+		t.Fatalf("OpenGroup failed: %v", err)
+	}
+	defer root.Close()
+
+	visits := map[string]int{}
+	kinds := map[string]string{}
+	err = root.Walk(ctx, func(path string, node zarr.Node) error {
+		visits[path]++
+		switch n := node.(type) {
+		case *zarr.Reader:
+			kinds[path] = "array"
+			if n.Path() != path {
+				t.Errorf("array at %s reports path %q", path, n.Path())
+			}
+		case *zarr.Group:
+			kinds[path] = "group"
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+
+	want := map[string]string{
+		"a":                 "array",
+		"labels":            "group",
+		"labels/mask":       "array",
+		"labels/deep":       "group",
+		"labels/deep/x":     "array",
+		"labels/deep/empty": "group",
+	}
+	if !maps.Equal(kinds, want) {
+		t.Errorf("expected nodes %v, got %v", want, kinds)
+	}
+	for path, n := range visits {
+		if n != 1 {
+			t.Errorf("expected %s to be visited once, got %d", path, n)
+		}
+	}
+
+	// Walking a sub-group reports paths relative to it.
+	labels, err := root.OpenGroup(ctx, "labels")
+	if err != nil {
+		t.Fatalf("OpenGroup failed: %v", err)
+	}
+	defer labels.Close()
+	var paths []string
+	if err := labels.Walk(ctx, func(path string, _ zarr.Node) error {
+		paths = append(paths, path)
+		return nil
+	}); err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+	slices.Sort(paths)
+	if want := []string{"deep", "deep/empty", "deep/x", "mask"}; !slices.Equal(paths, want) {
+		t.Errorf("expected %v, got %v", want, paths)
+	}
+
+	// An error from fn stops the walk below that node.
+	stop := errors.New("stop")
+	visited := 0
+	err = root.Walk(ctx, func(path string, _ zarr.Node) error {
+		visited++
+		if path == "labels" {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("expected the error from fn, got %v", err)
+	}
+	if visited > 2 {
+		t.Errorf("expected nothing below labels to be visited, got %d visits", visited)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := root.Walk(cancelled, func(string, zarr.Node) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
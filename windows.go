@@ -0,0 +1,76 @@
+package zarr
+
+import (
+	"context"
+	"fmt"
+)
+
+// Window is one region emitted by Reader.Windows. Err is set on the final
+// window sent when a read fails, in which case Data is nil.
+type Window struct {
+	Start []int
+	Data  []byte
+	Err   error
+}
+
+// Windows slides a window of windowShape over the array, moving stride
+// elements per step along each axis, and sends each window's position and
+// data on the returned channel in C order. Windows that would extend past the
+// edge of the array are not emitted. Reads happen as the consumer receives,
+// so a slow consumer applies backpressure; cancelling ctx stops the producer
+// and closes the channel.
+func (r *Reader) Windows(ctx context.Context, windowShape, stride []int) (<-chan Window, error) {
+	if r.viewErr != nil {
+		return nil, r.viewErr
+	}
+	shape := r.Shape()
+	if len(windowShape) != len(shape) || len(stride) != len(shape) {
+		return nil, fmt.Errorf("window shape and stride must match array dimensionality")
+	}
+	for i := range shape {
+		if windowShape[i] <= 0 || windowShape[i] > shape[i] {
+			return nil, fmt.Errorf("window does not fit the array at dimension %d", i)
+		}
+		if stride[i] <= 0 {
+			return nil, fmt.Errorf("stride must be positive at dimension %d", i)
+		}
+	}
+
+	windows := make(chan Window)
+	go func() {
+		defer close(windows)
+
+		send := func(w Window) bool {
+			if ctx.Err() != nil {
+				return false
+			}
+			select {
+			case windows <- w:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var iterate func(dim int, start []int) bool
+		iterate = func(dim int, start []int) bool {
+			if dim == len(shape) {
+				data, err := r.ReadRegion(ctx, start, windowShape)
+				if err != nil {
+					send(Window{Start: append([]int(nil), start...), Err: err})
+					return false
+				}
+				return send(Window{Start: append([]int(nil), start...), Data: data})
+			}
+			for s := 0; s+windowShape[dim] <= shape[dim]; s += stride[dim] {
+				start[dim] = s
+				if !iterate(dim+1, start) {
+					return false
+				}
+			}
+			return true
+		}
+		iterate(0, make([]int, len(shape)))
+	}()
+	return windows, nil
+}
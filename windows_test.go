@@ -0,0 +1,76 @@
+package zarr_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestReader_Windows(t *testing.T) {
+	reader := openSequential4x4(t)
+	ctx := context.Background()
+
+	windows, err := reader.Windows(ctx, []int{2, 2}, []int{1, 2})
+	if err != nil {
+		t.Fatalf("Windows failed: %v", err)
+	}
+
+	var starts [][]int
+	for w := range windows {
+		if w.Err != nil {
+			t.Fatalf("window at %v failed: %v", w.Start, w.Err)
+		}
+		starts = append(starts, w.Start)
+
+		want := map[string][]float32{
+			"[0 0]": {0, 1, 4, 5},
+			"[1 2]": {6, 7, 10, 11},
+			"[2 0]": {8, 9, 12, 13},
+		}
+		if exp, ok := want[fmt.Sprint(w.Start)]; ok {
+			if got := decodeFloat32(w.Data); !slices.Equal(got, exp) {
+				t.Errorf("window at %v: expected %v, got %v", w.Start, exp, got)
+			}
+		}
+	}
+
+	// Rows 0..2 with stride 1, columns 0 and 2 with stride 2.
+	if len(starts) != 6 {
+		t.Fatalf("expected 6 windows, got %d: %v", len(starts), starts)
+	}
+	if !slices.Equal(starts[5], []int{2, 2}) {
+		t.Errorf("expected the last window at [2 2], got %v", starts[5])
+	}
+}
+
+func TestReader_WindowsCancel(t *testing.T) {
+	reader := openSequential4x4(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	windows, err := reader.Windows(ctx, []int{1, 1}, []int{1, 1})
+	if err != nil {
+		t.Fatalf("Windows failed: %v", err)
+	}
+	<-windows
+	cancel()
+
+	// Only a send already in flight may still complete after cancelling.
+	n := 1
+	for range windows {
+		n++
+	}
+	if n > 2 {
+		t.Errorf("expected cancellation to stop the window producer, got %d windows", n)
+	}
+}
+
+func TestReader_WindowsInvalid(t *testing.T) {
+	reader := openSequential4x4(t)
+	if _, err := reader.Windows(context.Background(), []int{5, 1}, []int{1, 1}); err == nil {
+		t.Error("expected an error for a window larger than the array")
+	}
+	if _, err := reader.Windows(context.Background(), []int{2, 2}, []int{0, 1}); err == nil {
+		t.Error("expected an error for a zero stride")
+	}
+}
@@ -0,0 +1,242 @@
+package zarr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Writer creates a Zarr V2 array in a blob bucket and stores its chunks.
+type Writer struct {
+	store  *SharedBucket
+	ref    *bucketRef
+	prefix string
+	meta   *Metadata
+
+	encoding   ChunkEncoding
+	itemSize   int
+	chunkBytes int
+
+	// reader reads chunks back for WriteRegion. It shares the writer's
+	// bucket reference rather than holding its own.
+	reader *Reader
+
+	// dedup is set once EnableDedup has been called.
+	dedup *dedupState
+}
+
+// NewWriter opens the bucket at the given gocloud URL and creates an array
+// described by meta at its root, replacing any .zarray already there.
+func NewWriter(ctx context.Context, path string, meta *Metadata) (*Writer, error) {
+	store, err := OpenSharedBucket(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	w, err := store.CreateArray(ctx, "", meta)
+	// The writer holds its own reference, as for NewReader.
+	store.Close()
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// CreateArray validates meta and writes it as the .zarray of the array
+// under the given key prefix. A zero ZarrFormat defaults to 2 and an empty
+// Order to "C". The returned Writer holds its own reference to the bucket.
+func (s *SharedBucket) CreateArray(ctx context.Context, path string, meta *Metadata) (*Writer, error) {
+	if meta == nil {
+		return nil, fmt.Errorf("metadata is required")
+	}
+	m := *meta
+	m.Shape = slices.Clone(meta.Shape)
+	m.Chunks = slices.Clone(meta.Chunks)
+	if m.ZarrFormat == 0 {
+		m.ZarrFormat = 2
+	}
+	if m.Order == "" {
+		m.Order = "C"
+	}
+
+	w := &Writer{prefix: keyPrefix(path), meta: &m}
+	if err := w.init(); err != nil {
+		return nil, err
+	}
+
+	data, err := m.MarshalZArray()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.acquire(); err != nil {
+		return nil, err
+	}
+	w.store = s
+	w.ref = newBucketRef(s)
+	w.reader.store = s
+	if err := s.bucket.WriteAll(ctx, w.prefix+".zarray", data, nil); err != nil {
+		w.ref.release()
+		return nil, fmt.Errorf("failed to write .zarray: %w", err)
+	}
+	return w, nil
+}
+
+// init validates the metadata for writing and derives the chunk layout.
+func (w *Writer) init() error {
+	m := w.meta
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	_, itemSize, err := ParseDType(m.DType)
+	if err != nil {
+		return fmt.Errorf("invalid dtype: %w", err)
+	}
+	fill, err := encodeFill(m.DType, m.FillValue)
+	if err != nil {
+		return fmt.Errorf("failed to parse fill_value: %w", err)
+	}
+	if len(m.Filters) > 0 {
+		return fmt.Errorf("filters are not supported for writing")
+	}
+	if cfg := m.Compressor; cfg != nil {
+		switch cfg.ID {
+		case "gzip", "zlib", "zstd", "crc32c":
+		case "blosc":
+			if _, ok := bloscCodecs[cfg.Cname]; !ok {
+				return fmt.Errorf("unsupported blosc cname: %q", cfg.Cname)
+			}
+		default:
+			return fmt.Errorf("unsupported compressor: %s", cfg.ID)
+		}
+	}
+
+	chunkBytes, err := byteSize(m.Chunks, itemSize)
+	if err != nil {
+		return err
+	}
+	w.encoding = ChunkEncoding{Separator: m.DimensionSeparator}
+	w.itemSize = itemSize
+	w.chunkBytes = chunkBytes
+	w.reader = &Reader{prefix: w.prefix, meta: m, encoding: w.encoding, fill: fill, dedup: &dedupFlag{}}
+	return nil
+}
+
+// WriteChunk compresses a chunk with the array's compressor and stores it
+// under the chunk's key. data holds a full chunk, edge chunks included, in
+// the array's order and in little-endian byte order, as ReadChunk returns it;
+// it is byte-swapped on the way out for big-endian dtypes.
+func (w *Writer) WriteChunk(ctx context.Context, coords []int, data []byte) error {
+	grid := GridShape(w.meta.Shape, w.meta.Chunks)
+	if len(coords) != len(grid) {
+		return fmt.Errorf("chunk coordinates %v do not match array rank %d", coords, len(grid))
+	}
+	for i, c := range coords {
+		if c < 0 || c >= grid[i] {
+			return fmt.Errorf("chunk coordinates %v out of bounds for grid %v", coords, grid)
+		}
+	}
+	if len(data) != w.chunkBytes {
+		return fmt.Errorf("chunk has %d bytes, expected %d", len(data), w.chunkBytes)
+	}
+
+	if w.meta.DType[0] == '>' {
+		data = bytes.Clone(data)
+		width := w.itemSize
+		if w.meta.DType[1] == 'c' {
+			width /= 2
+		}
+		SwapBytes(data, width)
+	}
+	encoded, err := compress(data, w.meta.Compressor, w.itemSize)
+	if err != nil {
+		return err
+	}
+
+	key := w.encoding.Encode(coords)
+	if w.dedup != nil {
+		return w.writeDeduped(ctx, key, encoded)
+	}
+	if err := w.store.bucket.WriteAll(ctx, w.prefix+key, encoded, nil); err != nil {
+		return fmt.Errorf("failed to write chunk %s: %w", key, err)
+	}
+	return nil
+}
+
+// WriteRegion writes data, a C-order region of the given shape starting at
+// start, into the array. It is the inverse of ReadRegion: each chunk the
+// region touches is read back, or synthesized from the fill value when it is
+// missing, updated and written again. Chunks the region covers completely,
+// up to the array's edge, are built from the fill value and the region alone
+// without being read.
+func (w *Writer) WriteRegion(ctx context.Context, start, shape []int, data []byte) error {
+	rank := len(w.meta.Shape)
+	if len(start) != rank || len(shape) != rank {
+		return fmt.Errorf("start and shape must match array dimensionality")
+	}
+	for i := range w.meta.Shape {
+		if start[i] < 0 || shape[i] <= 0 || start[i]+shape[i] > w.meta.Shape[i] {
+			return fmt.Errorf("region out of bounds at dimension %d", i)
+		}
+	}
+	regionBytes, err := byteSize(shape, w.itemSize)
+	if err != nil {
+		return err
+	}
+	if len(data) != regionBytes {
+		return fmt.Errorf("region data has %d bytes, expected %d", len(data), regionBytes)
+	}
+
+	first := make([]int, rank)
+	last := make([]int, rank)
+	for i := range first {
+		first[i] = start[i] / w.meta.Chunks[i]
+		last[i] = (start[i] + shape[i] - 1) / w.meta.Chunks[i]
+	}
+	regionStrides := strides(shape)
+	chunkStrides := w.reader.chunkStrides()
+
+	return forEachChunk(first, last, ChunkOrderC, func(coords []int) error {
+		copyShape := make([]int, rank)
+		srcOffset := make([]int, rank)
+		dstOffset := make([]int, rank)
+		covered := true
+		for i, c := range coords {
+			chunkStart := c * w.meta.Chunks[i]
+			chunkEnd := min(chunkStart+w.meta.Chunks[i], w.meta.Shape[i])
+			lo := max(chunkStart, start[i])
+			hi := min(chunkEnd, start[i]+shape[i])
+			copyShape[i] = hi - lo
+			srcOffset[i] = lo - start[i]
+			dstOffset[i] = lo - chunkStart
+			covered = covered && lo == chunkStart && hi == chunkEnd
+		}
+
+		var chunk []byte
+		if covered {
+			chunk = make([]byte, w.chunkBytes)
+			w.reader.fillChunk(chunk)
+		} else {
+			chunk, err = w.reader.readChunk(ctx, coords, readOptions{})
+			if err != nil {
+				return err
+			}
+			if len(chunk) != w.chunkBytes {
+				return fmt.Errorf("chunk %s: %w: has %d bytes, expected %d", w.encoding.Encode(coords), ErrChunkCorrupt, len(chunk), w.chunkBytes)
+			}
+		}
+		copyND(chunk, chunkStrides, dstOffset, data, regionStrides, srcOffset, copyShape, w.itemSize)
+		return w.WriteChunk(ctx, coords, chunk)
+	})
+}
+
+// Metadata returns the metadata the array was created with.
+func (w *Writer) Metadata() *Metadata {
+	return w.meta
+}
+
+// Close releases the writer's reference to the bucket. Every WriteChunk has
+// completed its upload by the time it returns, so there is nothing left to
+// flush.
+func (w *Writer) Close() error {
+	return w.ref.release()
+}
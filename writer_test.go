@@ -0,0 +1,255 @@
+package zarr_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gocloud.dev/blob"
+
+	"github.com/TuSKan/go-zarr"
+)
+
+func TestWriter_RoundTrip(t *testing.T) {
+	shape, chunks := []int{5, 5}, []int{2, 3}
+	values := make([]float32, 25)
+	for i := range values {
+		values[i] = float32(i) * 1.5
+	}
+	ctx := context.Background()
+
+	compressors := map[string]*zarr.CompressorConfig{
+		"none":   nil,
+		"gzip":   {ID: "gzip", Level: 5},
+		"zlib":   {ID: "zlib"},
+		"zstd":   {ID: "zstd", Level: 3},
+		"blosc":  {ID: "blosc", Cname: "lz4", Clevel: 5, Shuffle: 1},
+		"crc32c": {ID: "crc32c"},
+	}
+	for name, cfg := range compressors {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			w, err := zarr.NewWriter(ctx, "file:///"+filepath.ToSlash(dir), &zarr.Metadata{
+				Shape:      shape,
+				Chunks:     chunks,
+				DType:      "<f4",
+				Compressor: cfg,
+				FillValue:  0.0,
+			})
+			if err != nil {
+				t.Fatalf("NewWriter failed: %v", err)
+			}
+			for key, chunk := range chunkFloat32(shape, chunks, values) {
+				var coords []int
+				for _, p := range strings.Split(key, ".") {
+					c, _ := strconv.Atoi(p)
+					coords = append(coords, c)
+				}
+				if err := w.WriteChunk(ctx, coords, encodeLE(t, chunk)); err != nil {
+					t.Fatalf("WriteChunk %s failed: %v", key, err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+
+			reader := openReader(t, dir)
+			if got := reader.Metadata(); got.Order != "C" || got.ZarrFormat != 2 {
+				t.Errorf("expected defaults order C and zarr_format 2, got %q and %d", got.Order, got.ZarrFormat)
+			}
+			data, err := reader.ReadFull(ctx)
+			if err != nil {
+				t.Fatalf("ReadFull failed: %v", err)
+			}
+			if got := decodeFloat32(data); !slices.Equal(got, values) {
+				t.Errorf("expected %v, got %v", values, got)
+			}
+		})
+	}
+}
+
+func TestWriter_BigEndian(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	w, err := zarr.NewWriter(ctx, "file:///"+filepath.ToSlash(dir), &zarr.Metadata{
+		Shape:  []int{3},
+		Chunks: []int{3},
+		DType:  ">i2",
+	})
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	if err := w.WriteChunk(ctx, []int{0}, encodeLE(t, []int16{-2, 300, 7})); err != nil {
+		t.Fatalf("WriteChunk failed: %v", err)
+	}
+	w.Close()
+
+	values, _, err := openReader(t, dir).ReadRegionReflect(ctx, []int{0}, []int{3})
+	if err != nil {
+		t.Fatalf("ReadRegionReflect failed: %v", err)
+	}
+	if got := values.Interface().([]int16); !slices.Equal(got, []int16{-2, 300, 7}) {
+		t.Errorf("expected [-2 300 7], got %v", got)
+	}
+}
+
+func TestWriter_Validation(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		meta zarr.Metadata
+	}{
+		{"rank mismatch", zarr.Metadata{Shape: []int{4, 4}, Chunks: []int{2}, DType: "<f4"}},
+		{"unknown dtype", zarr.Metadata{Shape: []int{4}, Chunks: []int{2}, DType: "<x4"}},
+		{"zero chunk", zarr.Metadata{Shape: []int{4}, Chunks: []int{0}, DType: "<f4"}},
+		{"bad fill value", zarr.Metadata{Shape: []int{4}, Chunks: []int{2}, DType: "<i2", FillValue: 0.5}},
+		{"unknown compressor", zarr.Metadata{Shape: []int{4}, Chunks: []int{2}, DType: "<f4", Compressor: &zarr.CompressorConfig{ID: "lzma"}}},
+		{"filters", zarr.Metadata{Shape: []int{4}, Chunks: []int{2}, DType: "<f4", Filters: []*zarr.CompressorConfig{{ID: "delta"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := zarr.NewWriter(ctx, "file:///"+filepath.ToSlash(t.TempDir()), &tt.meta)
+			if err == nil {
+				w.Close()
+				t.Fatal("expected NewWriter to reject the metadata")
+			}
+		})
+	}
+
+	w, err := zarr.NewWriter(ctx, "file:///"+filepath.ToSlash(t.TempDir()), &zarr.Metadata{
+		Shape: []int{4}, Chunks: []int{2}, DType: "<f4",
+	})
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	defer w.Close()
+	if err := w.WriteChunk(ctx, []int{2}, make([]byte, 8)); err == nil {
+		t.Error("expected out-of-bounds chunk coordinates to be rejected")
+	}
+	if err := w.WriteChunk(ctx, []int{1}, make([]byte, 4)); err == nil {
+		t.Error("expected a short chunk to be rejected")
+	}
+}
+
+func TestWriter_WriteRegion(t *testing.T) {
+	for _, order := range []string{"C", "F"} {
+		t.Run(order, func(t *testing.T) {
+			ctx := context.Background()
+			fb := newFakeBucket(nil)
+			store := zarr.NewSharedBucket(blob.NewBucket(fb))
+			defer store.Close()
+			w, err := store.CreateArray(ctx, "", &zarr.Metadata{
+				Shape:     []int{5, 7},
+				Chunks:    []int{2, 3},
+				DType:     "<i4",
+				FillValue: -1.0,
+				Order:     order,
+			})
+			if err != nil {
+				t.Fatalf("CreateArray failed: %v", err)
+			}
+			defer w.Close()
+
+			want := make([]int32, 35)
+			for i := range want {
+				want[i] = -1
+			}
+			write := func(start, shape []int, base int32) {
+				t.Helper()
+				values := make([]int32, shape[0]*shape[1])
+				for i := range values {
+					values[i] = base + int32(i)
+					want[(start[0]+i/shape[1])*7+start[1]+i%shape[1]] = values[i]
+				}
+				if err := w.WriteRegion(ctx, start, shape, encodeLE(t, values)); err != nil {
+					t.Fatalf("WriteRegion(%v, %v) failed: %v", start, shape, err)
+				}
+			}
+
+			write([]int{1, 2}, []int{3, 4}, 100)
+			fb.resetReads()
+			// Covers chunk 0.0 exactly, so it must not be read back.
+			write([]int{0, 0}, []int{2, 3}, 200)
+			if n := len(fb.readsOf("0.0")); n != 0 {
+				t.Errorf("expected a fully covered chunk to be written without reading it, got %d reads", n)
+			}
+			// Covers the in-bounds part of the corner edge chunk.
+			write([]int{4, 6}, []int{1, 1}, 300)
+
+			reader := openFake(t, fb)
+			values, _, err := reader.ReadRegionReflect(ctx, []int{0, 0}, []int{5, 7})
+			if err != nil {
+				t.Fatalf("ReadRegionReflect failed: %v", err)
+			}
+			if got := values.Interface().([]int32); !slices.Equal(got, want) {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+
+			edge, err := reader.ReadChunk(ctx, []int{2, 2})
+			if err != nil {
+				t.Fatalf("ReadChunk failed: %v", err)
+			}
+			if len(edge) != 2*3*4 {
+				t.Fatalf("expected the edge chunk to be padded to 24 bytes, got %d", len(edge))
+			}
+
+			if err := w.WriteRegion(ctx, []int{4, 0}, []int{2, 1}, make([]byte, 8)); err == nil {
+				t.Error("expected an out-of-bounds region to be rejected")
+			}
+			if err := w.WriteRegion(ctx, []int{0, 0}, []int{1, 1}, make([]byte, 3)); err == nil {
+				t.Error("expected mismatched region data to be rejected")
+			}
+		})
+	}
+}
+
+func TestWriter_GoFillValues(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		dtype string
+		fill  any
+		want  []byte
+	}{
+		{"<i4", -1, encodeLE(t, []int32{-1})},
+		{"<f4", float32(1.5), encodeLE(t, []float32{1.5})},
+		{">i2", int16(-300), encodeLE(t, []int16{-300})},
+		{"<u8", uint64(math.MaxUint64), encodeLE(t, []uint64{math.MaxUint64})},
+		{"<i8", int64(1<<53 + 1), encodeLE(t, []int64{1<<53 + 1})},
+		{"<c8", complex64(complex(1, -2)), encodeLE(t, []float32{1, -2})},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %v", tt.dtype, tt.fill), func(t *testing.T) {
+			dir := t.TempDir()
+			w, err := zarr.NewWriter(ctx, "file:///"+filepath.ToSlash(dir), &zarr.Metadata{
+				Shape: []int{3}, Chunks: []int{2}, DType: tt.dtype, FillValue: tt.fill,
+			})
+			if err != nil {
+				t.Fatalf("NewWriter failed: %v", err)
+			}
+			w.Close()
+
+			// No chunks were written, so every element reads as the fill
+			// value stored in .zarray.
+			data, err := openReader(t, dir).ReadFull(ctx)
+			if err != nil {
+				t.Fatalf("ReadFull failed: %v", err)
+			}
+			if want := bytes.Repeat(tt.want, 3); !bytes.Equal(data, want) {
+				t.Errorf("expected % x, got % x", want, data)
+			}
+		})
+	}
+
+	_, err := zarr.NewWriter(ctx, "file:///"+filepath.ToSlash(t.TempDir()), &zarr.Metadata{
+		Shape: []int{3}, Chunks: []int{2}, DType: "<i1", FillValue: 200,
+	})
+	if err == nil {
+		t.Error("expected an out-of-range int fill value to be rejected")
+	}
+}